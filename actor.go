@@ -1,12 +1,23 @@
 package fpgo
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var (
-	ErrActorAskTimeout = fmt.Errorf("ErrActorAskTimeout")
+	ErrActorAskTimeout     = fmt.Errorf("ErrActorAskTimeout")
+	ErrActorClosed         = fmt.Errorf("ErrActorClosed")
+	ErrActorMailboxFull    = fmt.Errorf("ErrActorMailboxFull")
+	ErrActorMailboxTimeout = fmt.Errorf("ErrActorMailboxTimeout")
+	ErrActorMessageType    = fmt.Errorf("ErrActorMessageType")
+	ErrActorRestarted      = fmt.Errorf("ErrActorRestarted")
+	ErrActorStashFull      = fmt.Errorf("ErrActorStashFull")
 )
 
 // ActorHandle A target could send messages
@@ -16,19 +27,116 @@ type ActorHandle[T any] interface {
 
 // ActorDef[T] Actor model inspired by Erlang/Akka
 type ActorDef[T any] struct {
-	id       time.Time
-	isClosed bool
+	id       uint64
+	name     string
+	path     string
+	isClosed AtomBool
+	stopOnce sync.Once
+	done     chan struct{}
 	ch       *chan T
+	mailbox  *actorMailbox[actorEnvelope[T]]
 	effect   func(*ActorDef[T], T)
+	// sender The sender of the current message
+	sender ActorRef
+
+	dispatcher    ActorDispatcher
+	syncM         sync.Mutex
+	terminateOnce sync.Once
+	interceptor   func(message T, sender ActorRef) bool
+	interceptorM  sync.RWMutex
+	// behaviors Behavior stack of Become/Unbecome, the top one overrides effect
+	behaviors []func(*ActorDef[T], T)
+
+	context     map[string]interface{}
+	initContext map[string]interface{}
+
+	children  map[uint64]*ActorDef[T]
+	childrenM sync.RWMutex
+	parent    *ActorDef[T]
+	system    *ActorSystemDef
+
+	supervisor *SupervisorStrategyDef
+	restarts   []time.Time
+	preStart   func(*ActorDef[T])
+	postStop   func(*ActorDef[T])
+	onRestart  func(*ActorDef[T])
+	stopPolicy ActorStopPolicy
+
+	receiveTimeout        time.Duration
+	receiveTimeoutMessage T
+
+	stash         []actorEnvelope[T]
+	stashCapacity int
+
+	// failureCause The panic cause if the Actor was stopped by a failure
+	failureCause interface{}
+	watchState   actorWatchState
+	metrics      actorMetrics
+
+	sysQueue  []func()
+	sysM      sync.Mutex
+	sysNotify chan struct{}
+}
+
+// ActorOption Options for creating/spawning Actors
+type ActorOption[T any] struct {
+	// Supervisor Decide what to do when the effect panics (nil: stop the Actor)
+	Supervisor *SupervisorStrategyDef
+	// Mailbox Capacity & overflow policy of the Mailbox (nil: unbounded)
+	Mailbox *MailboxOption[T]
+
+	// PreStart Hook called in the Actor goroutine before processing any messages (panics are handled by the Supervisor)
+	PreStart func(self *ActorDef[T])
+	// PostStop Hook called in the Actor goroutine after the Actor & its children stopped (panics are reported as ActorTerminated.Cause)
+	//
+	// It's also called before restarts by the Supervisor.
+	PostStop func(self *ActorDef[T])
+	// OnRestart Hook called in the Actor goroutine between PostStop & PreStart of restarts by the Supervisor,
+	// resetting states kept outside of the context (panics of it & PreStart during restarts stop the Actor)
+	OnRestart func(self *ActorDef[T])
+	// StopPolicy What to do with the queued messages when stopping
+	StopPolicy ActorStopPolicy
+	// StashCapacity Max stashed messages (<= 0: unbounded)
+	StashCapacity int
+	// Dispatcher Where messages are processed (ActorDispatcherDefault: in the Actor goroutine)
+	Dispatcher ActorDispatcher
+	// LatencyBuckets Ascending upper bounds of buckets of the processing latency histogram (nil: ActorDefaultLatencyBuckets())
+	LatencyBuckets []time.Duration
+}
 
-	context map[string]interface{}
+// ActorDispatcher Where the messages of the Actor are processed
+type ActorDispatcher int
+
+const (
+	// ActorDispatcherDefault Process messages in the Actor goroutine
+	ActorDispatcherDefault ActorDispatcher = iota
+	// ActorDispatcherCallingThread Process messages synchronously in the sender goroutine (for deterministic tests)
+	//
+	// Messages sent by the effect to the Actor itself are processed after the current one.
+	// SetReceiveTimeout() is not supported, and MailboxBlockWithTimeout may block forever.
+	ActorDispatcherCallingThread
+)
 
-	children map[time.Time]*ActorDef[T]
-	parent   *ActorDef[T]
+// actorEnvelope A message with its sender
+type actorEnvelope[T any] struct {
+	message T
+	sender  ActorRef
 }
 
+// ActorStopPolicy What to do with the queued messages when the Actor is stopping
+type ActorStopPolicy int
+
+const (
+	// ActorStopDiscard Discard the queued messages
+	ActorStopDiscard ActorStopPolicy = iota
+	// ActorStopDrain Process the queued messages before stopped (new messages are rejected)
+	ActorStopDrain
+)
+
 var defaultActor *ActorDef[interface{}]
 
+var actorIDCounter uint64
+
 // GetDefault Get Default Actor
 func (actorSelf *ActorDef[T]) GetDefault() *ActorDef[interface{}] {
 	return defaultActor
@@ -44,79 +152,582 @@ func (actorSelf *ActorDef[T]) NewByOptions(effect func(*ActorDef[T], T), ioCh *c
 	return ActorNewByOptionsGenerics(effect, ioCh, context)
 }
 
+// NewWithOption New Actor instance with ActorOption
+func (actorSelf *ActorDef[T]) NewWithOption(effect func(*ActorDef[T], T), option *ActorOption[T]) *ActorDef[T] {
+	return ActorNewWithOptionGenerics(effect, option)
+}
+
 // ActorNewGenerics New Actor instance
+//
+// Its Mailbox is unbounded: Send doesn't wait for the Actor to receive the message (use ActorOption.Mailbox for backpressure).
 func ActorNewGenerics[T any](effect func(*ActorDef[T], T)) *ActorDef[T] {
-	ch := make(chan T)
-	return ActorNewByOptionsGenerics(effect, &ch, map[string]interface{}{})
+	return actorNew(effect, nil, map[string]interface{}{}, nil).start()
 }
 
 // ActorNewByOptionsGenerics New Actor by its options
+//
+// Messages received from ioCh will be forwarded to the Mailbox of the Actor until it's terminated
+// (ioCh is owned by the caller, it's not closed by the Actor).
 func ActorNewByOptionsGenerics[T any](effect func(*ActorDef[T], T), ioCh *chan T, context map[string]interface{}) *ActorDef[T] {
+	return actorNew(effect, ioCh, context, nil).start()
+}
+
+// ActorNewWithOptionGenerics New Actor instance with ActorOption
+func ActorNewWithOptionGenerics[T any](effect func(*ActorDef[T], T), option *ActorOption[T]) *ActorDef[T] {
+	return actorNew(effect, nil, map[string]interface{}{}, option).start()
+}
+
+// actorNew New an Actor without starting it(its name/parent/system could be set before starting)
+func actorNew[T any](effect func(*ActorDef[T], T), ioCh *chan T, context map[string]interface{}, option *ActorOption[T]) *ActorDef[T] {
 	newOne := ActorDef[T]{
-		id:       time.Now(),
-		ch:       ioCh,
-		effect:   effect,
-		context:  context,
-		children: map[time.Time]*ActorDef[T]{},
+		id:          atomic.AddUint64(&actorIDCounter, 1),
+		ch:          ioCh,
+		effect:      effect,
+		context:     context,
+		initContext: DuplicateMap(context),
+		children:    map[uint64]*ActorDef[T]{},
+		sysNotify:   make(chan struct{}, 1),
+		done:        make(chan struct{}),
 	}
-
-	go newOne.run()
+	newOne.setName("", "")
+	var mailboxOption *MailboxOption[T]
+	var latencyBuckets []time.Duration
+	if option != nil {
+		newOne.supervisor = option.Supervisor
+		newOne.preStart = option.PreStart
+		newOne.postStop = option.PostStop
+		newOne.onRestart = option.OnRestart
+		newOne.stopPolicy = option.StopPolicy
+		newOne.stashCapacity = option.StashCapacity
+		newOne.dispatcher = option.Dispatcher
+		mailboxOption = option.Mailbox
+		latencyBuckets = option.LatencyBuckets
+	}
+	newOne.metrics = actorMetricsNew(latencyBuckets)
+	newOne.mailbox = newActorMailbox(envelopeMailboxOption(mailboxOption, newOne.publishDeadLetter))
 
 	return &newOne
 }
+func envelopeMailboxOption[T any](option *MailboxOption[T], publishDeadLetter func(actorEnvelope[T], error)) *MailboxOption[actorEnvelope[T]] {
+	var onDropped func(message T, err error)
+	envelopeOption := &MailboxOption[actorEnvelope[T]]{}
+	if option != nil {
+		envelopeOption.Capacity = option.Capacity
+		envelopeOption.Overflow = option.Overflow
+		envelopeOption.BlockTimeout = option.BlockTimeout
+		onDropped = option.OnDropped
+		if option.Priority != nil {
+			envelopeOption.Priority = func(envelope actorEnvelope[T]) int {
+				return option.Priority(envelope.message)
+			}
+		}
+		if option.IsControl != nil {
+			envelopeOption.IsControl = func(envelope actorEnvelope[T]) bool {
+				return option.IsControl(envelope.message)
+			}
+		}
+	}
+	envelopeOption.OnDropped = func(envelope actorEnvelope[T], err error) {
+		if onDropped != nil {
+			onDropped(envelope.message, err)
+		}
+		publishDeadLetter(envelope, err)
+	}
+
+	return envelopeOption
+}
+func (actorSelf *ActorDef[T]) start() *ActorDef[T] {
+	if actorSelf.dispatcher == ActorDispatcherCallingThread {
+		actorSelf.syncM.Lock()
+		actorSelf.callPreStart()
+		actorSelf.syncM.Unlock()
+		actorSelf.dispatchSync()
+	} else {
+		go actorSelf.run()
+	}
+	if actorSelf.ch != nil {
+		go actorSelf.forward(*actorSelf.ch)
+	}
+
+	return actorSelf
+}
+func (actorSelf *ActorDef[T]) setName(parentPath string, name string) {
+	if name == "" {
+		name = "$" + strconv.FormatUint(actorSelf.id, 10)
+	}
+	if parentPath == "" {
+		parentPath = ActorPathUser
+	}
+
+	actorSelf.name = name
+	actorSelf.path = parentPath + "/" + name
+}
 
 // Send Send a message to the Actor
+//
+// It returns after the message is queued in the Mailbox, not after the Actor received it;
+// use Done()/AwaitTermination() to wait for the queued messages being processed before stopping.
 func (actorSelf *ActorDef[T]) Send(message T) {
-	if actorSelf.isClosed {
-		return
+	actorSelf.TrySend(message)
+}
+
+// TrySend Send a message to the Actor, returns error if the Mailbox rejected it
+func (actorSelf *ActorDef[T]) TrySend(message T) error {
+	return actorSelf.Tell(message, nil)
+}
+
+// Tell Send a message with its sender(nil: unknown) to the Actor, returns error if the Mailbox rejected it
+//
+// Rejected messages are reported to the DeadLetters.
+func (actorSelf *ActorDef[T]) Tell(message T, sender ActorRef) error {
+	envelope := actorEnvelope[T]{message: message, sender: sender}
+	if actorSelf.isClosed.Get() {
+		actorSelf.publishDeadLetter(envelope, ErrActorClosed)
+		return ErrActorClosed
+	}
+
+	actorSelf.interceptorM.RLock()
+	interceptor := actorSelf.interceptor
+	actorSelf.interceptorM.RUnlock()
+	if interceptor != nil && !interceptor(message, sender) {
+		return nil
+	}
+
+	err := actorSelf.mailbox.offer(envelope)
+	if err == ErrActorClosed {
+		// Overflowed messages have been reported by the Mailbox
+		actorSelf.publishDeadLetter(envelope, err)
+	}
+	if err == nil && actorSelf.dispatcher == ActorDispatcherCallingThread {
+		actorSelf.dispatchSync()
+	}
+	return err
+}
+
+// TellForInterface Send a message(must be T) with its sender(nil: unknown) to the Actor
+func (actorSelf *ActorDef[T]) TellForInterface(message interface{}, sender ActorRef) error {
+	typed, ok := message.(T)
+	if !ok {
+		return ErrActorMessageType
 	}
 
-	*(actorSelf.ch) <- message
+	return actorSelf.Tell(typed, sender)
+}
+
+// SetInterceptor Intercept messages sent to the Actor (nil: remove it), e.g. for tests
+//
+// interceptor is called in the sender goroutine before the message is queued,
+// the message is dropped silently if it returns false.
+func (actorSelf *ActorDef[T]) SetInterceptor(interceptor func(message T, sender ActorRef) bool) {
+	actorSelf.interceptorM.Lock()
+	actorSelf.interceptor = interceptor
+	actorSelf.interceptorM.Unlock()
+}
+
+// GetSender Get the sender of the current message (nil: unknown)
+//
+// It should be called in the Actor goroutine (e.g. in the effect).
+func (actorSelf *ActorDef[T]) GetSender() ActorRef {
+	return actorSelf.sender
 }
 
 // Spawn Spawn a new Actor with parent(this actor)
 func (actorSelf *ActorDef[T]) Spawn(effect func(*ActorDef[T], T)) *ActorDef[T] {
-	newOne := actorSelf.New(effect)
-	if actorSelf.isClosed {
-		return newOne
+	return actorSelf.SpawnWithOption(effect, nil)
+}
+
+// SpawnWithOption Spawn a new Actor with parent(this actor) and ActorOption
+//
+// If it couldn't be spawned(e.g. this Actor/ActorSystem stopped), a terminated Actor is returned,
+// the error is its ActorTerminated.Cause (use SpawnNamed to get the error directly).
+func (actorSelf *ActorDef[T]) SpawnWithOption(effect func(*ActorDef[T], T), option *ActorOption[T]) *ActorDef[T] {
+	newOne, err := actorSelf.SpawnNamed("", effect, option)
+	if err != nil {
+		newOne = actorNew(effect, nil, map[string]interface{}{}, option)
+		newOne.parent = actorSelf
+		newOne.system = actorSelf.system
+		newOne.setName(actorSelf.path, "")
+		return newOne.terminateWithoutStart(err)
+	}
+
+	return newOne
+}
+
+// SpawnNamed Spawn a new Actor with parent(this actor), its unique name among siblings and ActorOption
+//
+// The name is generated if it's empty.
+func (actorSelf *ActorDef[T]) SpawnNamed(name string, effect func(*ActorDef[T], T), option *ActorOption[T]) (*ActorDef[T], error) {
+	if strings.Contains(name, "/") {
+		return nil, ErrActorInvalidName
+	}
+	if actorSelf.isClosed.Get() {
+		return nil, ErrActorClosed
 	}
 
+	newOne := actorNew(effect, nil, map[string]interface{}{}, option)
 	newOne.parent = actorSelf
+	newOne.system = actorSelf.system
+	newOne.setName(actorSelf.path, name)
+
+	actorSelf.childrenM.Lock()
+	for id, child := range actorSelf.children {
+		if !child.IsClosed() {
+			if child.name == newOne.name {
+				actorSelf.childrenM.Unlock()
+				return nil, ErrActorNameConflict
+			}
+			continue
+		}
+		// Prune stopped children
+		delete(actorSelf.children, id)
+	}
 	actorSelf.children[newOne.id] = newOne
+	actorSelf.childrenM.Unlock()
+
+	if newOne.system != nil {
+		err := newOne.system.register(newOne)
+		if err != nil {
+			actorSelf.childrenM.Lock()
+			delete(actorSelf.children, newOne.id)
+			actorSelf.childrenM.Unlock()
+			return nil, err
+		}
+	}
 
-	return newOne
+	return newOne.start(), nil
+}
+
+// Become Switch the message handler (the old one is kept in the behavior stack unless discardOld)
+//
+// It should be called in the Actor goroutine (e.g. in the effect).
+func (actorSelf *ActorDef[T]) Become(behavior func(*ActorDef[T], T), discardOld bool) {
+	if discardOld && len(actorSelf.behaviors) > 0 {
+		actorSelf.behaviors[len(actorSelf.behaviors)-1] = behavior
+		return
+	}
+
+	actorSelf.behaviors = append(actorSelf.behaviors, behavior)
+}
+
+// Unbecome Switch back to the previous message handler in the behavior stack
+//
+// It should be called in the Actor goroutine (e.g. in the effect).
+func (actorSelf *ActorDef[T]) Unbecome() {
+	if len(actorSelf.behaviors) > 0 {
+		actorSelf.behaviors = actorSelf.behaviors[:len(actorSelf.behaviors)-1]
+	}
 }
 
 // GetChild Get a child Actor by ID
-func (actorSelf *ActorDef[T]) GetChild(id time.Time) *ActorDef[T] {
+func (actorSelf *ActorDef[T]) GetChild(id uint64) *ActorDef[T] {
+	actorSelf.childrenM.RLock()
+	defer actorSelf.childrenM.RUnlock()
 	return actorSelf.children[id]
 }
 
+// GetChildren Get all child Actors
+func (actorSelf *ActorDef[T]) GetChildren() []*ActorDef[T] {
+	actorSelf.childrenM.RLock()
+	defer actorSelf.childrenM.RUnlock()
+	return Values(actorSelf.children)
+}
+
 // GetParent Get its parent Actor
 func (actorSelf *ActorDef[T]) GetParent() *ActorDef[T] {
 	return actorSelf.parent
 }
 
-// GetID Get ID time.Time
-func (actorSelf *ActorDef[T]) GetID() time.Time {
+// GetChildByName Get a child Actor by its name
+func (actorSelf *ActorDef[T]) GetChildByName(name string) *ActorDef[T] {
+	actorSelf.childrenM.RLock()
+	defer actorSelf.childrenM.RUnlock()
+	for _, child := range actorSelf.children {
+		if child.name == name && !child.IsClosed() {
+			return child
+		}
+	}
+	return nil
+}
+
+// GetID Get the unique ID
+func (actorSelf *ActorDef[T]) GetID() uint64 {
 	return actorSelf.id
 }
 
-// Close Close the Actor
+// GetName Get the name (unique among siblings)
+func (actorSelf *ActorDef[T]) GetName() string {
+	return actorSelf.name
+}
+
+// GetPath Get the hierarchical path (e.g. /user/orders/worker-3)
+func (actorSelf *ActorDef[T]) GetPath() string {
+	return actorSelf.path
+}
+
+// GetSystem Get the ActorSystem (nil if it's not created by any ActorSystem)
+func (actorSelf *ActorDef[T]) GetSystem() *ActorSystemDef {
+	return actorSelf.system
+}
+
+// SendForInterface Send a message(must be T) to the Actor
+func (actorSelf *ActorDef[T]) SendForInterface(message interface{}) error {
+	return actorSelf.TellForInterface(message, nil)
+}
+
+// Close Close the Actor (same as Stop)
 func (actorSelf *ActorDef[T]) Close() {
-	actorSelf.isClosed = true
+	actorSelf.Stop()
+}
 
-	close(*actorSelf.ch)
+// Stop Stop the Actor by its ActorStopPolicy, its children will be stopped recursively
+//
+// It's safe to be called concurrently, new messages are rejected since then.
+// Use Done()/AwaitTermination() to wait for the termination.
+func (actorSelf *ActorDef[T]) Stop() {
+	if actorSelf.isClosed.Get() {
+		return
+	}
+
+	actorSelf.stopOnce.Do(func() {
+		actorSelf.isClosed.Set(true)
+
+		discarded := actorSelf.mailbox.close(actorSelf.stopPolicy == ActorStopDiscard)
+		for _, envelope := range discarded {
+			actorSelf.publishDeadLetter(envelope, ErrActorClosed)
+		}
+		if actorSelf.system != nil {
+			actorSelf.system.unregister(actorSelf)
+		}
+	})
+	if actorSelf.dispatcher == ActorDispatcherCallingThread {
+		actorSelf.dispatchSync()
+	}
 }
 
 // IsClosed Check is Closed
 func (actorSelf *ActorDef[T]) IsClosed() bool {
-	return actorSelf.isClosed
+	return actorSelf.isClosed.Get()
+}
+
+// GetMailboxSize Get the number of queued messages
+func (actorSelf *ActorDef[T]) GetMailboxSize() int {
+	return actorSelf.mailbox.size()
+}
+
+// Done Get the channel which will be closed after the Actor terminated(PostStop called)
+func (actorSelf *ActorDef[T]) Done() <-chan struct{} {
+	return actorSelf.done
+}
+
+// AwaitTermination Wait for the termination of the Actor until ctx is done
+func (actorSelf *ActorDef[T]) AwaitTermination(ctx context.Context) error {
+	select {
+	case <-actorSelf.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (actorSelf *ActorDef[T]) run() {
-	for message := range *actorSelf.ch {
-		actorSelf.effect(actorSelf, message)
+	defer actorSelf.terminate()
+
+	actorSelf.callPreStart()
+	for {
+		var idleTimer *time.Timer
+		var idle <-chan time.Time
+		if actorSelf.receiveTimeout > 0 {
+			idleTimer = time.NewTimer(actorSelf.receiveTimeout)
+			idle = idleTimer.C
+		}
+
+		select {
+		case <-actorSelf.sysNotify:
+			actorSelf.runSystem()
+		case <-actorSelf.mailbox.notEmpty:
+			actorSelf.processMailbox()
+		case <-idle:
+			actorSelf.receive(actorEnvelope[T]{message: actorSelf.receiveTimeoutMessage})
+		case <-actorSelf.mailbox.closed:
+			if idleTimer != nil {
+				idleTimer.Stop()
+			}
+			// Drain the remaining messages if they're not discarded
+			actorSelf.processMailbox()
+			return
+		}
+
+		if idleTimer != nil {
+			idleTimer.Stop()
+		}
+	}
+}
+func (actorSelf *ActorDef[T]) processMailbox() {
+	for {
+		// System operations(e.g. restarts by the supervisor) go first
+		actorSelf.runSystem()
+
+		envelope, ok := actorSelf.mailbox.poll()
+		if !ok {
+			return
+		}
+		actorSelf.receive(envelope)
+	}
+}
+func (actorSelf *ActorDef[T]) terminate() {
+	children := actorSelf.GetChildren()
+	for _, child := range children {
+		child.Stop()
+	}
+	for _, child := range children {
+		<-child.Done()
+	}
+
+	actorSelf.callPostStop()
+	// Stashed messages won't be received anymore
+	stash := actorSelf.stash
+	actorSelf.stash = nil
+	for _, envelope := range stash {
+		actorSelf.publishDeadLetter(envelope, ErrActorClosed)
+	}
+	actorSelf.publishTerminated()
+	close(actorSelf.done)
+}
+
+// terminateWithoutStart Terminate the Actor which isn't started (no hooks are called)
+func (actorSelf *ActorDef[T]) terminateWithoutStart(cause interface{}) *ActorDef[T] {
+	actorSelf.failureCause = cause
+	actorSelf.stopOnce.Do(func() {
+		actorSelf.isClosed.Set(true)
+		actorSelf.mailbox.close(true)
+	})
+	actorSelf.publishTerminated()
+	close(actorSelf.done)
+
+	return actorSelf
+}
+
+// callPreStart Call PreStart, its failure(panic) is handled like the ones of the effect
+func (actorSelf *ActorDef[T]) callPreStart() {
+	if cause := actorSelf.callHook(actorSelf.preStart); cause != nil {
+		actorSelf.handleFailure(cause)
+	}
+}
+
+// callPostStop Call PostStop, its failure(panic) is reported to the watchers as the cause (if it's stopped & there's none)
+func (actorSelf *ActorDef[T]) callPostStop() {
+	cause := actorSelf.callHook(actorSelf.postStop)
+	if cause != nil && actorSelf.IsClosed() && actorSelf.failureCause == nil {
+		actorSelf.failureCause = cause
+	}
+}
+
+// callHook Call the hook(nil: nothing), returns its panic cause
+func (actorSelf *ActorDef[T]) callHook(hook func(*ActorDef[T])) (cause interface{}) {
+	if hook == nil {
+		return nil
+	}
+	defer func() {
+		cause = recover()
+		if cause != nil {
+			atomic.AddUint64(&actorSelf.metrics.failures, 1)
+		}
+	}()
+
+	hook(actorSelf)
+	return nil
+}
+func (actorSelf *ActorDef[T]) forward(ioCh chan T) {
+	for {
+		select {
+		case message, ok := <-ioCh:
+			if !ok {
+				return
+			}
+			actorSelf.Send(message)
+		case <-actorSelf.done:
+			return
+		}
+	}
+}
+func (actorSelf *ActorDef[T]) receive(envelope actorEnvelope[T]) {
+	actorSelf.sender = envelope.sender
+	startTime := time.Now()
+	defer func() {
+		actorSelf.sender = nil
+		actorSelf.metrics.recordLatency(time.Since(startTime))
+		if cause := recover(); cause != nil {
+			atomic.AddUint64(&actorSelf.metrics.failures, 1)
+			actorSelf.handleFailure(cause)
+		}
+	}()
+
+	actorSelf.currentBehavior()(actorSelf, envelope.message)
+}
+func (actorSelf *ActorDef[T]) currentBehavior() func(*ActorDef[T], T) {
+	if len(actorSelf.behaviors) > 0 {
+		return actorSelf.behaviors[len(actorSelf.behaviors)-1]
+	}
+
+	return actorSelf.effect
+}
+
+// postSystem Post an operation to be executed by the Actor goroutine before next messages
+func (actorSelf *ActorDef[T]) postSystem(fn func()) {
+	actorSelf.sysM.Lock()
+	actorSelf.sysQueue = append(actorSelf.sysQueue, fn)
+	actorSelf.sysM.Unlock()
+
+	select {
+	case actorSelf.sysNotify <- struct{}{}:
+	default:
+	}
+	if actorSelf.dispatcher == ActorDispatcherCallingThread {
+		actorSelf.dispatchSync()
+	}
+}
+func (actorSelf *ActorDef[T]) runSystem() {
+	actorSelf.sysM.Lock()
+	queue := actorSelf.sysQueue
+	actorSelf.sysQueue = nil
+	actorSelf.sysM.Unlock()
+
+	for _, fn := range queue {
+		fn()
+	}
+}
+
+// dispatchSync Process the pending messages/operations in the caller goroutine (ActorDispatcherCallingThread)
+func (actorSelf *ActorDef[T]) dispatchSync() {
+	// Others may queue messages after the current processing, check again after unlocking
+	for actorSelf.hasPendingSync() && actorSelf.syncM.TryLock() {
+		actorSelf.processMailbox()
+		select {
+		case <-actorSelf.mailbox.closed:
+			actorSelf.terminateOnce.Do(actorSelf.terminate)
+		default:
+		}
+		actorSelf.syncM.Unlock()
+	}
+}
+func (actorSelf *ActorDef[T]) hasPendingSync() bool {
+	actorSelf.sysM.Lock()
+	pendingSystem := len(actorSelf.sysQueue) > 0
+	actorSelf.sysM.Unlock()
+	if pendingSystem || actorSelf.mailbox.size() > 0 {
+		return true
+	}
+
+	select {
+	case <-actorSelf.done:
+		return false
+	default:
+	}
+	select {
+	case <-actorSelf.mailbox.closed:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -125,12 +736,25 @@ var Actor ActorDef[interface{}]
 
 // AskDef[T, R] Ask inspired by Erlang/Akka
 type AskDef[T any, R any] struct {
-	id time.Time
-	ch *chan R
+	id       time.Time
+	ch       *chan R
+	done     chan struct{}
+	doneOnce sync.Once
 
 	Message T
+	// CorrelationID ID for correlating the request & the reply (generated by default)
+	CorrelationID string
 }
 
+// AskResponseWithError Response of Ask with Error
+type AskResponseWithError[R any] struct {
+	Response R
+
+	Err error
+}
+
+var askCorrelationIDCounter uint64
+
 // New New Ask instance
 func (askSelf *AskDef[T, R]) New(message T) *AskDef[T, R] {
 	return AskNewGenerics[T, R](message)
@@ -143,17 +767,20 @@ func (askSelf *AskDef[T, R]) NewByOptions(message T, ioCh *chan R) *AskDef[T, R]
 
 // AskNewGenerics New Ask instance
 func AskNewGenerics[T any, R any](message T) *AskDef[T, R] {
-	ch := make(chan R)
+	// Buffered: the replying Actor won't be blocked even if the sender is gone
+	ch := make(chan R, 1)
 	return AskNewByOptionsGenerics[T, R](message, &ch)
 }
 
 // AskNewByOptionsGenerics New Ask by its options
 func AskNewByOptionsGenerics[T any, R any](message T, ioCh *chan R) *AskDef[T, R] {
 	newOne := AskDef[T, R]{
-		id: time.Now(),
-		ch: ioCh,
+		id:   time.Now(),
+		ch:   ioCh,
+		done: make(chan struct{}),
 
-		Message: message,
+		Message:       message,
+		CorrelationID: strconv.FormatUint(atomic.AddUint64(&askCorrelationIDCounter, 1), 10),
 	}
 
 	return &newOne
@@ -161,18 +788,58 @@ func AskNewByOptionsGenerics[T any, R any](message T, ioCh *chan R) *AskDef[T, R
 
 // AskOnce Sender Ask
 func (askSelf *AskDef[T, R]) AskOnce(target ActorHandle[interface{}], timeout *time.Duration) (R, error) {
-	ch := askSelf.AskChannel(target)
-	defer close(*ch)
+	ctx := context.Background()
+	if timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	return askSelf.AskOnceWithContext(ctx, target)
+}
+
+// AskOnceWithContext Sender Ask, waiting for the reply until ctx is done
+func (askSelf *AskDef[T, R]) AskOnceWithContext(ctx context.Context, target ActorHandle[interface{}]) (R, error) {
+	return AskWithWrapperGenerics(ctx, target, askSelf, func(ask *AskDef[T, R]) interface{} {
+		return ask
+	})
+}
+
+// AskWithContext Sender Ask to a typed target, waiting for the reply until ctx is done
+func (askSelf *AskDef[T, R]) AskWithContext(ctx context.Context, target ActorHandle[*AskDef[T, R]]) (R, error) {
+	return AskWithWrapperGenerics(ctx, target, askSelf, func(ask *AskDef[T, R]) *AskDef[T, R] {
+		return ask
+	})
+}
+
+// AskIO Sender Ask to a typed target lazily (a new Ask is sent for each evaluation)
+func (askSelf *AskDef[T, R]) AskIO(ctx context.Context, target ActorHandle[*AskDef[T, R]]) *MonadIODef[*AskResponseWithError[R]] {
+	return MonadIONewGenerics(func() *AskResponseWithError[R] {
+		ask := AskNewGenerics[T, R](askSelf.Message)
+		ask.CorrelationID = askSelf.CorrelationID
+
+		response, err := ask.AskWithContext(ctx, target)
+		return &AskResponseWithError[R]{Response: response, Err: err}
+	})
+}
+
+// AskWithWrapperGenerics Sender Ask, the Ask is wrapped into the message type of the target
+//
+// The Ask is done after returning, late replies will be dropped.
+// ErrActorAskTimeout is returned if the deadline of ctx exceeded.
+func AskWithWrapperGenerics[M any, T any, R any](ctx context.Context, target ActorHandle[M], ask *AskDef[T, R], wrap func(*AskDef[T, R]) M) (R, error) {
 	var result R
-	// var err error
-	if timeout == nil {
-		result = <-*ch
-	} else {
-		select {
-		case result = <-*ch:
-		case <-time.After(*timeout):
-			return result, ErrActorAskTimeout
+	defer ask.markDone()
+
+	target.Send(wrap(ask))
+	select {
+	case result = <-*ask.ch:
+	case <-ctx.Done():
+		err := ctx.Err()
+		if err == context.DeadlineExceeded {
+			err = ErrActorAskTimeout
 		}
+		return result, err
 	}
 
 	return result, nil
@@ -185,9 +852,34 @@ func (askSelf *AskDef[T, R]) AskChannel(target ActorHandle[interface{}]) *chan R
 	return askSelf.ch
 }
 
-// Reply Receiver Reply
+// Reply Receiver Reply (dropped if the sender is gone)
 func (askSelf *AskDef[T, R]) Reply(response R) {
-	*askSelf.ch <- response
+	select {
+	case <-askSelf.done:
+		return
+	default:
+	}
+
+	select {
+	case *askSelf.ch <- response:
+	case <-askSelf.done:
+	}
+}
+
+// IsDone Is the Ask done (the sender is gone)
+func (askSelf *AskDef[T, R]) IsDone() bool {
+	select {
+	case <-askSelf.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (askSelf *AskDef[T, R]) markDone() {
+	askSelf.doneOnce.Do(func() {
+		close(askSelf.done)
+	})
 }
 
 // Ask Ask utils instance
@@ -197,6 +889,6 @@ func init() {
 	// Ask = *Ask.New(0, nil)
 	// Actor = *Actor.New(func(_ *ActorDef[interface{}], _ interface{}) {})
 	// Actor.Close()
-	Actor.isClosed = true
+	Actor.isClosed.Set(true)
 	defaultActor = &Actor
 }
@@ -0,0 +1,214 @@
+package fpgo
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// BackoffRestartPolicy When BackoffSupervisor restarts the child
+type BackoffRestartPolicy int
+
+const (
+	// BackoffRestartOnStop Restart the child whenever it terminated (by failures or stopped)
+	BackoffRestartOnStop BackoffRestartPolicy = iota
+	// BackoffRestartOnFailure Restart the child only if it's stopped by failures
+	BackoffRestartOnFailure
+)
+
+// BackoffSupervisorOption Options for creating BackoffSupervisors
+type BackoffSupervisorOption[T any] struct {
+	// MinBackoff The delay of the first restart, it's doubled for each attempt
+	MinBackoff time.Duration
+	// MaxBackoff The max delay of restarts (<= 0: unlimited)
+	MaxBackoff time.Duration
+	// RandomFactor Jitter, the delay is increased randomly by up to delay*RandomFactor
+	RandomFactor float64
+	// ResetAfter The attempts are reset if the child has run for the stable period (<= 0: never)
+	ResetAfter time.Duration
+	// MaxAttempts Give up after the number of restarts (<= 0: unlimited)
+	MaxAttempts int
+	// Restart When the child is restarted
+	Restart BackoffRestartPolicy
+	// Child Options of the child
+	Child *ActorOption[T]
+}
+
+// BackoffSupervisorDef[T] BackoffSupervisor inspired by Akka, it restarts the child with exponential backoff
+//
+// It looks like a single ActorHandle, messages are forwarded to the current child
+// (they're reported to the DeadLetters while the child is down).
+// It's stopped when its parent terminated or the child can't be spawned by the parent.
+type BackoffSupervisorDef[T any] struct {
+	parent            *ActorDef[T]
+	parentTermination *Subscription[ActorTerminated]
+	effect            func(*ActorDef[T], T)
+	option            BackoffSupervisorOption[T]
+
+	isClosed     AtomBool
+	stopOnce     sync.Once
+	done         chan struct{}
+	child        *ActorDef[T]
+	childStarted time.Time
+	attempts     int
+	timer        *time.Timer
+	childM       sync.Mutex
+}
+
+// BackoffSupervisorNewGenerics New a BackoffSupervisor with the child spawned by the parent (nil: the child has no parent)
+func BackoffSupervisorNewGenerics[T any](parent *ActorDef[T], effect func(*ActorDef[T], T), option BackoffSupervisorOption[T]) *BackoffSupervisorDef[T] {
+	newOne := &BackoffSupervisorDef[T]{
+		parent: parent,
+		effect: effect,
+		option: option,
+		done:   make(chan struct{}),
+	}
+	newOne.childM.Lock()
+	if parent != nil {
+		newOne.parentTermination = parent.SubscribeTermination(func(ActorTerminated) {
+			// Not in the caller goroutine: it may hold childM
+			go newOne.Stop()
+		})
+	}
+	newOne.spawnChild()
+	newOne.childM.Unlock()
+
+	return newOne
+}
+
+// Send Send a message to the current child
+func (backoffSelf *BackoffSupervisorDef[T]) Send(message T) {
+	backoffSelf.TrySend(message)
+}
+
+// TrySend Send a message to the current child, returns error if it's rejected
+func (backoffSelf *BackoffSupervisorDef[T]) TrySend(message T) error {
+	if backoffSelf.isClosed.Get() {
+		return ErrActorClosed
+	}
+
+	// The terminated child reports it to the DeadLetters
+	return backoffSelf.GetChild().TrySend(message)
+}
+
+// GetChild Get the current child (it's terminated while backing off, nil if it has never been spawned)
+func (backoffSelf *BackoffSupervisorDef[T]) GetChild() *ActorDef[T] {
+	backoffSelf.childM.Lock()
+	defer backoffSelf.childM.Unlock()
+	return backoffSelf.child
+}
+
+// GetAttempts Get the number of restarts since the last reset
+func (backoffSelf *BackoffSupervisorDef[T]) GetAttempts() int {
+	backoffSelf.childM.Lock()
+	defer backoffSelf.childM.Unlock()
+	return backoffSelf.attempts
+}
+
+// Stop Stop the BackoffSupervisor & its child, no more restarts
+func (backoffSelf *BackoffSupervisorDef[T]) Stop() {
+	backoffSelf.childM.Lock()
+	child := backoffSelf.child
+	backoffSelf.giveUp()
+	backoffSelf.childM.Unlock()
+
+	if child != nil {
+		child.Stop()
+	}
+}
+
+// IsClosed Check is Closed
+func (backoffSelf *BackoffSupervisorDef[T]) IsClosed() bool {
+	return backoffSelf.isClosed.Get()
+}
+
+// Done Get the channel which will be closed after it's stopped or gave up (MaxAttempts reached)
+func (backoffSelf *BackoffSupervisorDef[T]) Done() <-chan struct{} {
+	return backoffSelf.done
+}
+
+// spawnChild Spawn the child which is restarted when it's terminated (childM should be locked)
+//
+// Errors of spawning by the parent(e.g. it's stopped) are final, it gives up.
+func (backoffSelf *BackoffSupervisorDef[T]) spawnChild() {
+	var child *ActorDef[T]
+	if backoffSelf.parent != nil {
+		var err error
+		child, err = backoffSelf.parent.SpawnNamed("", backoffSelf.effect, backoffSelf.option.Child)
+		if err != nil {
+			backoffSelf.giveUp()
+			return
+		}
+	} else {
+		child = ActorNewWithOptionGenerics(backoffSelf.effect, backoffSelf.option.Child)
+	}
+	backoffSelf.child = child
+	backoffSelf.childStarted = time.Now()
+
+	child.SubscribeTermination(func(terminated ActorTerminated) {
+		// Not in the caller goroutine: it may hold childM
+		go backoffSelf.onChildTerminated(child, terminated)
+	})
+}
+func (backoffSelf *BackoffSupervisorDef[T]) onChildTerminated(child *ActorDef[T], terminated ActorTerminated) {
+	backoffSelf.childM.Lock()
+	defer backoffSelf.childM.Unlock()
+	if backoffSelf.isClosed.Get() || backoffSelf.child != child {
+		return
+	}
+
+	if backoffSelf.option.Restart == BackoffRestartOnFailure && terminated.Cause == nil {
+		backoffSelf.giveUp()
+		return
+	}
+	if backoffSelf.option.ResetAfter > 0 && time.Since(backoffSelf.childStarted) >= backoffSelf.option.ResetAfter {
+		backoffSelf.attempts = 0
+	}
+	if backoffSelf.option.MaxAttempts > 0 && backoffSelf.attempts >= backoffSelf.option.MaxAttempts {
+		backoffSelf.giveUp()
+		return
+	}
+
+	delay := backoffSelf.backoff(backoffSelf.attempts)
+	backoffSelf.attempts++
+	backoffSelf.timer = time.AfterFunc(delay, func() {
+		backoffSelf.childM.Lock()
+		defer backoffSelf.childM.Unlock()
+		if backoffSelf.isClosed.Get() {
+			return
+		}
+		backoffSelf.spawnChild()
+	})
+}
+
+// backoff The delay of the attempt: min(MinBackoff * 2^attempt, MaxBackoff) with jitter
+func (backoffSelf *BackoffSupervisorDef[T]) backoff(attempt int) time.Duration {
+	option := backoffSelf.option
+	delay := option.MinBackoff
+	for i := 0; i < attempt && delay < math.MaxInt64/2 && (option.MaxBackoff <= 0 || delay < option.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if option.MaxBackoff > 0 && delay > option.MaxBackoff {
+		delay = option.MaxBackoff
+	}
+	if option.RandomFactor > 0 {
+		delay += time.Duration(float64(delay) * option.RandomFactor * rand.Float64())
+	}
+
+	return delay
+}
+
+// giveUp No more restarts (childM should be locked)
+func (backoffSelf *BackoffSupervisorDef[T]) giveUp() {
+	backoffSelf.stopOnce.Do(func() {
+		backoffSelf.isClosed.Set(true)
+		if backoffSelf.timer != nil {
+			backoffSelf.timer.Stop()
+		}
+		if backoffSelf.parent != nil {
+			backoffSelf.parent.UnsubscribeTermination(backoffSelf.parentTermination)
+		}
+		close(backoffSelf.done)
+	})
+}
@@ -0,0 +1,116 @@
+package fpgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorBackoffSupervisor(t *testing.T) {
+	started := make(chan time.Time, 10)
+	option := BackoffSupervisorOption[string]{
+		MinBackoff:  10 * time.Millisecond,
+		MaxBackoff:  30 * time.Millisecond,
+		ResetAfter:  50 * time.Millisecond,
+		MaxAttempts: 3,
+		Child: &ActorOption[string]{
+			PreStart: func(self *ActorDef[string]) {
+				started <- time.Now()
+			},
+		},
+	}
+	supervisor := BackoffSupervisorNewGenerics(nil, func(self *ActorDef[string], input string) {
+		switch input {
+		case "boom":
+			panic(input)
+		case "stop":
+			self.Stop()
+		}
+	}, option)
+	last := <-started
+
+	// Exponential backoff
+	for _, expected := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond} {
+		supervisor.Send("boom")
+		current := <-started
+		assert.GreaterOrEqual(t, current.Sub(last), expected)
+		last = current
+	}
+	assert.Equal(t, 2, supervisor.GetAttempts())
+
+	// Reset after the stable period
+	time.Sleep(option.ResetAfter)
+	supervisor.Send("stop")
+	<-started
+	assert.Equal(t, 1, supervisor.GetAttempts())
+
+	// Give up after MaxAttempts
+	supervisor.Send("boom")
+	<-started
+	supervisor.Send("boom")
+	<-started
+	supervisor.Send("boom")
+	<-supervisor.Done()
+	assert.Equal(t, true, supervisor.IsClosed())
+	assert.Equal(t, ErrActorClosed, supervisor.TrySend("hello"))
+
+	// Backoff delays
+	supervisor = BackoffSupervisorNewGenerics(nil, func(self *ActorDef[string], input string) {}, BackoffSupervisorOption[string]{
+		MinBackoff:   10 * time.Millisecond,
+		MaxBackoff:   40 * time.Millisecond,
+		RandomFactor: 0.5,
+		Restart:      BackoffRestartOnFailure,
+	})
+	for attempt, expected := range []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond} {
+		delay := supervisor.backoff(attempt)
+		assert.GreaterOrEqual(t, delay, expected)
+		assert.LessOrEqual(t, delay, expected*3/2)
+	}
+
+	// Stopped normally: no restarts with BackoffRestartOnFailure
+	supervisor.GetChild().Stop()
+	<-supervisor.Done()
+
+	// Failures of PreStart (e.g. connecting) are restarted with BackoffRestartOnFailure
+	preStarts := make(chan int, 10)
+	preStartCount := 0
+	supervisor = BackoffSupervisorNewGenerics(nil, func(self *ActorDef[string], input string) {}, BackoffSupervisorOption[string]{
+		MinBackoff: time.Millisecond,
+		Restart:    BackoffRestartOnFailure,
+		Child: &ActorOption[string]{
+			PreStart: func(self *ActorDef[string]) {
+				preStartCount++
+				preStarts <- preStartCount
+				if preStartCount < 3 {
+					panic("connection refused")
+				}
+			},
+		},
+	})
+	assert.Equal(t, 1, <-preStarts)
+	assert.Equal(t, 2, <-preStarts)
+	assert.Equal(t, 3, <-preStarts)
+	assert.Equal(t, false, supervisor.IsClosed())
+	assert.Equal(t, 2, supervisor.GetAttempts())
+	supervisor.Stop()
+
+	// Stopped with the parent (no children outside the parent)
+	parent := ActorNewGenerics(func(self *ActorDef[string], input string) {})
+	supervisor = BackoffSupervisorNewGenerics(parent, func(self *ActorDef[string], input string) {}, BackoffSupervisorOption[string]{
+		MinBackoff: time.Millisecond,
+	})
+	child := supervisor.GetChild()
+	assert.Equal(t, parent, child.GetParent())
+	parent.Stop()
+	<-parent.Done()
+	<-supervisor.Done()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, child, supervisor.GetChild())
+	assert.True(t, child.IsClosed())
+	// Spawning by a stopped parent is final
+	supervisor = BackoffSupervisorNewGenerics(parent, func(self *ActorDef[string], input string) {}, BackoffSupervisorOption[string]{})
+	<-supervisor.Done()
+	assert.Nil(t, supervisor.GetChild())
+	assert.Equal(t, ErrActorClosed, supervisor.TrySend("hello"))
+}
@@ -0,0 +1,114 @@
+package fpgo
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+var (
+	ErrActorUnhandled = fmt.Errorf("ErrActorUnhandled")
+)
+
+// ActorDeadLetter A message which couldn't be delivered to or handled by its recipient
+type ActorDeadLetter struct {
+	Message interface{}
+	// Sender The sender of the message (nil: unknown)
+	Sender    ActorRef
+	Recipient ActorRef
+	// Reason Why it's a dead letter (e.g. ErrActorClosed, ErrActorMailboxFull, ErrActorUnhandled)
+	Reason error
+}
+
+// DeadLettersDef Dead letter channel inspired by Akka, it's subscribable & counts the dead letters
+//
+// Each ActorSystem has its own one, Actors without ActorSystems report to the default one.
+type DeadLettersDef struct {
+	publisher *PublisherDef[ActorDeadLetter]
+
+	deadLetterCount uint64
+	unhandledCount  uint64
+}
+
+var defaultDeadLetters *DeadLettersDef
+
+// GetDefault Get Default DeadLetters (for Actors without ActorSystems)
+func (deadLettersSelf *DeadLettersDef) GetDefault() *DeadLettersDef {
+	return defaultDeadLetters
+}
+
+// New New DeadLetters instance
+func (deadLettersSelf *DeadLettersDef) New() *DeadLettersDef {
+	return &DeadLettersDef{
+		publisher: PublisherNewGenerics[ActorDeadLetter](),
+	}
+}
+
+// Subscribe Subscribe the dead letters
+//
+// fn is called in the goroutine reporting the dead letter unless GetPublisher().SubscribeOn() is set.
+func (deadLettersSelf *DeadLettersDef) Subscribe(fn func(ActorDeadLetter)) *Subscription[ActorDeadLetter] {
+	return deadLettersSelf.publisher.Subscribe(Subscription[ActorDeadLetter]{OnNext: fn})
+}
+
+// Unsubscribe Unsubscribe the dead letters by the Subscription
+func (deadLettersSelf *DeadLettersDef) Unsubscribe(s *Subscription[ActorDeadLetter]) {
+	deadLettersSelf.publisher.Unsubscribe(s)
+}
+
+// GetPublisher Get the underlying Publisher of the dead letters
+func (deadLettersSelf *DeadLettersDef) GetPublisher() *PublisherDef[ActorDeadLetter] {
+	return deadLettersSelf.publisher
+}
+
+// GetDeadLetterCount Get the number of undelivered messages
+func (deadLettersSelf *DeadLettersDef) GetDeadLetterCount() uint64 {
+	return atomic.LoadUint64(&deadLettersSelf.deadLetterCount)
+}
+
+// GetUnhandledCount Get the number of unhandled messages
+func (deadLettersSelf *DeadLettersDef) GetUnhandledCount() uint64 {
+	return atomic.LoadUint64(&deadLettersSelf.unhandledCount)
+}
+
+// Publish Report a dead letter
+func (deadLettersSelf *DeadLettersDef) Publish(deadLetter ActorDeadLetter) {
+	if deadLetter.Reason == ErrActorUnhandled {
+		atomic.AddUint64(&deadLettersSelf.unhandledCount, 1)
+	} else {
+		atomic.AddUint64(&deadLettersSelf.deadLetterCount, 1)
+	}
+
+	deadLettersSelf.publisher.Publish(deadLetter)
+}
+
+// GetDeadLetters Get the DeadLetters this Actor reports to
+func (actorSelf *ActorDef[T]) GetDeadLetters() *DeadLettersDef {
+	if actorSelf.system != nil {
+		return actorSelf.system.GetDeadLetters()
+	}
+
+	return defaultDeadLetters
+}
+
+// Unhandled Report the message as unhandled (e.g. in the effect for unknown messages)
+//
+// It should be called in the Actor goroutine (e.g. in the effect).
+func (actorSelf *ActorDef[T]) Unhandled(message T) {
+	actorSelf.publishDeadLetter(actorEnvelope[T]{message: message, sender: actorSelf.sender}, ErrActorUnhandled)
+}
+
+func (actorSelf *ActorDef[T]) publishDeadLetter(envelope actorEnvelope[T], reason error) {
+	actorSelf.GetDeadLetters().Publish(ActorDeadLetter{
+		Message:   envelope.message,
+		Sender:    envelope.sender,
+		Recipient: actorSelf,
+		Reason:    reason,
+	})
+}
+
+// DeadLetters DeadLetters utils instance
+var DeadLetters DeadLettersDef
+
+func init() {
+	defaultDeadLetters = DeadLetters.New()
+}
@@ -0,0 +1,86 @@
+package fpgo
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorDeadLetters(t *testing.T) {
+	system := ActorSystem.New("deadLetters")
+	defer system.Shutdown()
+
+	var deadLettersM sync.Mutex
+	var deadLetters []ActorDeadLetter
+	subscription := system.GetDeadLetters().Subscribe(func(deadLetter ActorDeadLetter) {
+		deadLettersM.Lock()
+		deadLetters = append(deadLetters, deadLetter)
+		deadLettersM.Unlock()
+	})
+	getDeadLetters := func() []ActorDeadLetter {
+		deadLettersM.Lock()
+		defer deadLettersM.Unlock()
+		return DuplicateSlice(deadLetters)
+	}
+
+	sender, _ := ActorOfGenerics(system, "sender", func(self *ActorDef[string], input string) {}, nil)
+	var senders []ActorRef
+	processed := make(chan struct{}, 1)
+	held := make(chan struct{})
+	gate := make(chan struct{})
+	recipient, _ := ActorOfGenerics(system, "recipient", func(self *ActorDef[string], input string) {
+		switch input {
+		case "hold":
+			close(held)
+			<-gate
+			return
+		case "known":
+			senders = append(senders, self.GetSender())
+		default:
+			self.Unhandled(input)
+		}
+		processed <- struct{}{}
+	}, &ActorOption[string]{
+		Mailbox: &MailboxOption[string]{Capacity: 1, Overflow: MailboxFailFast},
+	})
+
+	// Unhandled
+	assert.Equal(t, nil, recipient.Tell("known", sender))
+	<-processed
+	assert.Equal(t, nil, recipient.Tell("unknown", sender))
+	<-processed
+	recipient.Send("known")
+	<-processed
+
+	// Overflow
+	recipient.Send("hold")
+	<-held
+	assert.Equal(t, nil, recipient.Tell("queued", sender))
+	assert.Equal(t, ErrActorMailboxFull, recipient.Tell("overflow", sender))
+
+	// Discarded by Stop & sent after Stop
+	recipient.Stop()
+	close(gate)
+	<-recipient.Done()
+	assert.Equal(t, ErrActorClosed, recipient.TrySend("closed"))
+
+	assert.Equal(t, []ActorRef{sender, nil}, senders)
+	assert.Equal(t, []ActorDeadLetter{
+		{Message: "unknown", Sender: sender, Recipient: recipient, Reason: ErrActorUnhandled},
+		{Message: "overflow", Sender: sender, Recipient: recipient, Reason: ErrActorMailboxFull},
+		{Message: "queued", Sender: sender, Recipient: recipient, Reason: ErrActorClosed},
+		{Message: "closed", Sender: nil, Recipient: recipient, Reason: ErrActorClosed},
+	}, getDeadLetters())
+	assert.Equal(t, uint64(3), system.GetDeadLetters().GetDeadLetterCount())
+	assert.Equal(t, uint64(1), system.GetDeadLetters().GetUnhandledCount())
+
+	// Actors without ActorSystems report to the default one
+	system.GetDeadLetters().Unsubscribe(subscription)
+	count := DeadLetters.GetDefault().GetDeadLetterCount()
+	actor := ActorNewGenerics(func(self *ActorDef[int], input int) {})
+	actor.Stop()
+	actor.Send(1)
+	assert.Equal(t, count+1, DeadLetters.GetDefault().GetDeadLetterCount())
+	assert.Equal(t, 4, len(getDeadLetters()))
+}
@@ -0,0 +1,144 @@
+package fpgo
+
+import (
+	"reflect"
+	"sync"
+)
+
+// EventStreamDef EventStream inspired by Akka, a pub/sub bus classifying events by Go types or topics
+//
+// Events are delivered to the mailboxes of subscribers, not called back in the publisher goroutine.
+// Each ActorSystem has its own one, and subscriptions are removed when subscribers terminated.
+type EventStreamDef struct {
+	types   map[reflect.Type]*PublisherDef[interface{}]
+	topics  map[string]*PublisherDef[interface{}]
+	streamM sync.RWMutex
+}
+
+// EventStreamSubscription A subscription of the EventStream
+type EventStreamSubscription struct {
+	subscriber   ActorRef
+	publisher    *PublisherDef[interface{}]
+	subscription *Subscription[interface{}]
+	termination  *Subscription[ActorTerminated]
+}
+
+var defaultEventStream *EventStreamDef
+
+// GetDefault Get Default EventStream
+func (streamSelf *EventStreamDef) GetDefault() *EventStreamDef {
+	return defaultEventStream
+}
+
+// New New EventStream instance
+func (streamSelf *EventStreamDef) New() *EventStreamDef {
+	return &EventStreamDef{
+		types:  map[reflect.Type]*PublisherDef[interface{}]{},
+		topics: map[string]*PublisherDef[interface{}]{},
+	}
+}
+
+// EventStreamSubscribeGenerics Subscribe events of E (including the ones implementing E if it's an interface type)
+//
+// T of the subscriber should be able to hold E, otherwise events are dropped.
+func EventStreamSubscribeGenerics[E any](stream *EventStreamDef, subscriber ActorRef) *EventStreamSubscription {
+	eventType := reflect.TypeOf((*E)(nil)).Elem()
+
+	stream.streamM.Lock()
+	publisher := stream.types[eventType]
+	if publisher == nil {
+		publisher = PublisherNewGenerics[interface{}]()
+		stream.types[eventType] = publisher
+	}
+	stream.streamM.Unlock()
+
+	return stream.subscribe(publisher, subscriber)
+}
+
+// SubscribeTopic Subscribe events of the topic
+func (streamSelf *EventStreamDef) SubscribeTopic(topic string, subscriber ActorRef) *EventStreamSubscription {
+	streamSelf.streamM.Lock()
+	publisher := streamSelf.topics[topic]
+	if publisher == nil {
+		publisher = PublisherNewGenerics[interface{}]()
+		streamSelf.topics[topic] = publisher
+	}
+	streamSelf.streamM.Unlock()
+
+	return streamSelf.subscribe(publisher, subscriber)
+}
+
+// Unsubscribe Unsubscribe the EventStream by the EventStreamSubscription
+func (streamSelf *EventStreamDef) Unsubscribe(s *EventStreamSubscription) {
+	if s == nil {
+		return
+	}
+
+	s.publisher.Unsubscribe(s.subscription)
+	s.subscriber.UnsubscribeTermination(s.termination)
+}
+
+// Publish Publish the event to the subscribers of its type (and the interface types it implements)
+func (streamSelf *EventStreamDef) Publish(event interface{}) {
+	eventType := reflect.TypeOf(event)
+	if eventType == nil {
+		return
+	}
+
+	var publishers []*PublisherDef[interface{}]
+	streamSelf.streamM.RLock()
+	for subscribedType, publisher := range streamSelf.types {
+		if eventType.AssignableTo(subscribedType) {
+			publishers = append(publishers, publisher)
+		}
+	}
+	streamSelf.streamM.RUnlock()
+
+	for _, publisher := range publishers {
+		publisher.Publish(event)
+	}
+}
+
+// PublishTopic Publish the event to the subscribers of the topic
+func (streamSelf *EventStreamDef) PublishTopic(topic string, event interface{}) {
+	streamSelf.streamM.RLock()
+	publisher := streamSelf.topics[topic]
+	streamSelf.streamM.RUnlock()
+
+	if publisher != nil {
+		publisher.Publish(event)
+	}
+}
+
+func (streamSelf *EventStreamDef) subscribe(publisher *PublisherDef[interface{}], subscriber ActorRef) *EventStreamSubscription {
+	s := &EventStreamSubscription{
+		subscriber: subscriber,
+		publisher:  publisher,
+	}
+	s.subscription = publisher.Subscribe(Subscription[interface{}]{
+		OnNext: func(event interface{}) {
+			subscriber.TellForInterface(event, nil)
+		},
+	})
+	s.termination = subscriber.SubscribeTermination(func(ActorTerminated) {
+		publisher.Unsubscribe(s.subscription)
+	})
+
+	return s
+}
+
+// GetEventStream Get the EventStream of the ActorSystem of this Actor (the default one if it has no ActorSystem)
+func (actorSelf *ActorDef[T]) GetEventStream() *EventStreamDef {
+	if actorSelf.system != nil {
+		return actorSelf.system.GetEventStream()
+	}
+
+	return defaultEventStream
+}
+
+// EventStream EventStream utils instance
+var EventStream EventStreamDef
+
+func init() {
+	defaultEventStream = EventStream.New()
+}
@@ -0,0 +1,52 @@
+package fpgo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorEventStream(t *testing.T) {
+	system := ActorSystem.New("eventStream")
+	defer system.Shutdown()
+	stream := system.GetEventStream()
+
+	received := make(chan interface{}, 10)
+	subscriber, _ := ActorOfGenerics(system, "subscriber", func(self *ActorDef[interface{}], input interface{}) {
+		received <- input
+	}, nil)
+	assert.Equal(t, stream, subscriber.GetEventStream())
+
+	EventStreamSubscribeGenerics[string](stream, subscriber)
+	errorSubscription := EventStreamSubscribeGenerics[error](stream, subscriber)
+	stream.SubscribeTopic("orders", subscriber)
+
+	// By types (including interface types)
+	stream.Publish(1)
+	stream.Publish("hello")
+	assert.Equal(t, "hello", <-received)
+	err := fmt.Errorf("failed")
+	stream.Publish(err)
+	assert.Equal(t, err, <-received)
+
+	// By topics
+	stream.PublishTopic("users", "user-1")
+	stream.PublishTopic("orders", 2)
+	assert.Equal(t, 2, <-received)
+
+	// Unsubscribe
+	stream.Unsubscribe(errorSubscription)
+	stream.Publish(err)
+	stream.Publish("world")
+	assert.Equal(t, "world", <-received)
+
+	// Removed after the subscriber terminated
+	subscriber.Stop()
+	<-subscriber.Done()
+	publisher := stream.topics["orders"]
+	publisher.subscribeM.Lock()
+	assert.Equal(t, 0, len(publisher.subscribers))
+	publisher.subscribeM.Unlock()
+	assert.Equal(t, 0, len(received))
+}
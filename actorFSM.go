@@ -0,0 +1,246 @@
+package fpgo
+
+import (
+	"sync"
+	"time"
+)
+
+// FSMEvent[T, D] An event handled by FSM state handlers
+type FSMEvent[T any, D any] struct {
+	Message T
+	// Data The data of the current state
+	Data D
+	// IsStateTimeout The state timeout expired(Message is empty)
+	IsStateTimeout bool
+}
+
+// FSMNextState[S, D] What to do after the event is handled, made by Goto()/Stay()/Finish()
+type FSMNextState[S comparable, D any] struct {
+	state      S
+	data       D
+	hasData    bool
+	timeout    time.Duration
+	hasTimeout bool
+	isGoto     bool
+	isFinish   bool
+}
+
+// Using Replace the data of the next state
+func (nextSelf *FSMNextState[S, D]) Using(data D) *FSMNextState[S, D] {
+	nextSelf.data = data
+	nextSelf.hasData = true
+	return nextSelf
+}
+
+// ForMax Override the state timeout of the next state (0: disable)
+func (nextSelf *FSMNextState[S, D]) ForMax(timeout time.Duration) *FSMNextState[S, D] {
+	nextSelf.timeout = timeout
+	nextSelf.hasTimeout = true
+	return nextSelf
+}
+
+// FSMStateHandler[T, S, D] Handle the event in a state, returns nil if it's unhandled
+type FSMStateHandler[T any, S comparable, D any] func(fsm *FSMDef[T, S, D], event FSMEvent[T, D]) *FSMNextState[S, D]
+
+// FSMDef[T, S, D] Finite-state machine Actor inspired by Akka FSM
+//
+// Messages(T) are handled by the handler of the current state(S), and each state has its typed data(D).
+// Declare states by When()/WhenUnhandled()/OnTransition() and then Start() it.
+type FSMDef[T any, S comparable, D any] struct {
+	*ActorDef[T]
+
+	state     S
+	data      D
+	initState S
+	initData  D
+	stateM    sync.RWMutex
+
+	states      map[S]fsmState[T, S, D]
+	unhandled   FSMStateHandler[T, S, D]
+	transitions []func(from S, to S, data D)
+
+	timer             *time.Timer
+	timeoutGeneration uint64
+}
+
+type fsmState[T any, S comparable, D any] struct {
+	handler FSMStateHandler[T, S, D]
+	timeout time.Duration
+}
+
+// FSMNewGenerics New a FSM in the initial state with its data (it's started by Start())
+func FSMNewGenerics[T any, S comparable, D any](initState S, initData D) *FSMDef[T, S, D] {
+	return &FSMDef[T, S, D]{
+		state:     initState,
+		data:      initData,
+		initState: initState,
+		initData:  initData,
+		states:    map[S]fsmState[T, S, D]{},
+	}
+}
+
+// When Declare the handler of the state, the state timeout event is sent if no messages are received within timeout (0: disable)
+func (fsmSelf *FSMDef[T, S, D]) When(state S, timeout time.Duration, handler FSMStateHandler[T, S, D]) *FSMDef[T, S, D] {
+	fsmSelf.states[state] = fsmState[T, S, D]{handler: handler, timeout: timeout}
+	return fsmSelf
+}
+
+// WhenUnhandled Declare the handler of events unhandled by state handlers
+//
+// Unhandled messages are reported by ActorDef.Unhandled() if it's not declared.
+func (fsmSelf *FSMDef[T, S, D]) WhenUnhandled(handler FSMStateHandler[T, S, D]) *FSMDef[T, S, D] {
+	fsmSelf.unhandled = handler
+	return fsmSelf
+}
+
+// OnTransition Add a hook called on transitions made by Goto()
+func (fsmSelf *FSMDef[T, S, D]) OnTransition(hook func(from S, to S, data D)) *FSMDef[T, S, D] {
+	fsmSelf.transitions = append(fsmSelf.transitions, hook)
+	return fsmSelf
+}
+
+// Start Start the FSM Actor with ActorOption
+//
+// Restarts by the Supervisor return to the initial state with its data.
+func (fsmSelf *FSMDef[T, S, D]) Start(option *ActorOption[T]) *FSMDef[T, S, D] {
+	var actorOption ActorOption[T]
+	if option != nil {
+		actorOption = *option
+	}
+	preStart := actorOption.PreStart
+	actorOption.PreStart = func(self *ActorDef[T]) {
+		fsmSelf.armStateTimeout(fsmSelf.states[fsmSelf.state].timeout)
+		if preStart != nil {
+			preStart(self)
+		}
+	}
+	postStop := actorOption.PostStop
+	actorOption.PostStop = func(self *ActorDef[T]) {
+		fsmSelf.armStateTimeout(0)
+		if postStop != nil {
+			postStop(self)
+		}
+	}
+	onRestart := actorOption.OnRestart
+	actorOption.OnRestart = func(self *ActorDef[T]) {
+		fsmSelf.stateM.Lock()
+		fsmSelf.state = fsmSelf.initState
+		fsmSelf.data = fsmSelf.initData
+		fsmSelf.stateM.Unlock()
+		if onRestart != nil {
+			onRestart(self)
+		}
+	}
+
+	fsmSelf.ActorDef = actorNew(func(self *ActorDef[T], message T) {
+		fsmSelf.handle(FSMEvent[T, D]{Message: message, Data: fsmSelf.GetData()})
+	}, nil, map[string]interface{}{}, &actorOption)
+	fsmSelf.ActorDef.start()
+
+	return fsmSelf
+}
+
+// Goto Transit to the state
+func (fsmSelf *FSMDef[T, S, D]) Goto(state S) *FSMNextState[S, D] {
+	return &FSMNextState[S, D]{state: state, isGoto: true}
+}
+
+// Stay Stay in the current state (the state timeout is restarted)
+func (fsmSelf *FSMDef[T, S, D]) Stay() *FSMNextState[S, D] {
+	return &FSMNextState[S, D]{state: fsmSelf.GetState()}
+}
+
+// Finish Stop the FSM Actor
+func (fsmSelf *FSMDef[T, S, D]) Finish() *FSMNextState[S, D] {
+	return &FSMNextState[S, D]{state: fsmSelf.GetState(), isFinish: true}
+}
+
+// GetState Get the current state
+func (fsmSelf *FSMDef[T, S, D]) GetState() S {
+	fsmSelf.stateM.RLock()
+	defer fsmSelf.stateM.RUnlock()
+	return fsmSelf.state
+}
+
+// GetData Get the data of the current state
+func (fsmSelf *FSMDef[T, S, D]) GetData() D {
+	fsmSelf.stateM.RLock()
+	defer fsmSelf.stateM.RUnlock()
+	return fsmSelf.data
+}
+
+func (fsmSelf *FSMDef[T, S, D]) handle(event FSMEvent[T, D]) {
+	var next *FSMNextState[S, D]
+	if state, ok := fsmSelf.states[fsmSelf.GetState()]; ok {
+		next = state.handler(fsmSelf, event)
+	}
+	if next == nil && fsmSelf.unhandled != nil {
+		next = fsmSelf.unhandled(fsmSelf, event)
+	}
+	if next == nil {
+		if !event.IsStateTimeout {
+			fsmSelf.ActorDef.Unhandled(event.Message)
+		}
+		next = fsmSelf.Stay()
+	}
+
+	fsmSelf.apply(next)
+}
+func (fsmSelf *FSMDef[T, S, D]) apply(next *FSMNextState[S, D]) {
+	if next.isFinish {
+		fsmSelf.armStateTimeout(0)
+		fsmSelf.ActorDef.Stop()
+		return
+	}
+
+	fsmSelf.stateM.Lock()
+	from := fsmSelf.state
+	fsmSelf.state = next.state
+	if next.hasData {
+		fsmSelf.data = next.data
+	}
+	data := fsmSelf.data
+	fsmSelf.stateM.Unlock()
+
+	if next.isGoto {
+		for _, hook := range fsmSelf.transitions {
+			hook(from, next.state, data)
+		}
+	}
+
+	timeout := fsmSelf.states[next.state].timeout
+	if next.hasTimeout {
+		timeout = next.timeout
+	}
+	fsmSelf.armStateTimeout(timeout)
+}
+
+// armStateTimeout Restart the state timeout (0: disable), it should be called in the Actor goroutine
+func (fsmSelf *FSMDef[T, S, D]) armStateTimeout(timeout time.Duration) {
+	// Timeouts of previous states are ignored by the generation
+	fsmSelf.timeoutGeneration++
+	if fsmSelf.timer != nil {
+		fsmSelf.timer.Stop()
+		fsmSelf.timer = nil
+	}
+	if timeout <= 0 {
+		return
+	}
+
+	generation := fsmSelf.timeoutGeneration
+	actor := fsmSelf.ActorDef
+	fsmSelf.timer = time.AfterFunc(timeout, func() {
+		actor.postSystem(func() {
+			if generation != fsmSelf.timeoutGeneration || actor.IsClosed() {
+				return
+			}
+			defer func() {
+				if cause := recover(); cause != nil {
+					actor.handleFailure(cause)
+				}
+			}()
+
+			fsmSelf.handle(FSMEvent[T, D]{Data: fsmSelf.GetData(), IsStateTimeout: true})
+		})
+	})
+}
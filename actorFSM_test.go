@@ -0,0 +1,93 @@
+package fpgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fsmTestState int
+
+const (
+	fsmTestDisconnected fsmTestState = iota
+	fsmTestConnecting
+	fsmTestConnected
+)
+
+type fsmTestData struct {
+	attempts int
+	buffer   []string
+}
+
+func TestActorFSM(t *testing.T) {
+	type transition struct {
+		from     fsmTestState
+		to       fsmTestState
+		attempts int
+	}
+	transitions := make(chan transition, 10)
+	unhandled := make(chan string, 10)
+
+	fsm := FSMNewGenerics[string](fsmTestDisconnected, fsmTestData{}).
+		When(fsmTestDisconnected, 0, func(fsm *FSMDef[string, fsmTestState, fsmTestData], event FSMEvent[string, fsmTestData]) *FSMNextState[fsmTestState, fsmTestData] {
+			if event.Message == "connect" {
+				return fsm.Goto(fsmTestConnecting).Using(fsmTestData{attempts: event.Data.attempts + 1})
+			}
+			return nil
+		}).
+		When(fsmTestConnecting, 10*time.Millisecond, func(fsm *FSMDef[string, fsmTestState, fsmTestData], event FSMEvent[string, fsmTestData]) *FSMNextState[fsmTestState, fsmTestData] {
+			switch {
+			case event.IsStateTimeout:
+				return fsm.Goto(fsmTestDisconnected)
+			case event.Message == "connected":
+				return fsm.Goto(fsmTestConnected)
+			}
+			return nil
+		}).
+		When(fsmTestConnected, 0, func(fsm *FSMDef[string, fsmTestState, fsmTestData], event FSMEvent[string, fsmTestData]) *FSMNextState[fsmTestState, fsmTestData] {
+			switch event.Message {
+			case "close":
+				return fsm.Finish()
+			case "connect":
+				return nil
+			}
+			data := event.Data
+			data.buffer = append(DuplicateSlice(data.buffer), event.Message)
+			return fsm.Stay().Using(data)
+		}).
+		WhenUnhandled(func(fsm *FSMDef[string, fsmTestState, fsmTestData], event FSMEvent[string, fsmTestData]) *FSMNextState[fsmTestState, fsmTestData] {
+			unhandled <- event.Message
+			return fsm.Stay()
+		}).
+		OnTransition(func(from fsmTestState, to fsmTestState, data fsmTestData) {
+			transitions <- transition{from: from, to: to, attempts: data.attempts}
+		}).
+		Start(nil)
+
+	// The state timeout
+	fsm.Send("connect")
+	assert.Equal(t, transition{fsmTestDisconnected, fsmTestConnecting, 1}, <-transitions)
+	assert.Equal(t, transition{fsmTestConnecting, fsmTestDisconnected, 1}, <-transitions)
+
+	// Transitions with data
+	fsm.Send("connect")
+	assert.Equal(t, transition{fsmTestDisconnected, fsmTestConnecting, 2}, <-transitions)
+	fsm.Send("connected")
+	assert.Equal(t, transition{fsmTestConnecting, fsmTestConnected, 2}, <-transitions)
+	fsm.Send("a")
+	fsm.Send("b")
+	fsm.Send("connect")
+	assert.Equal(t, "connect", <-unhandled)
+	assert.Equal(t, fsmTestConnected, fsm.GetState())
+	assert.Equal(t, fsmTestData{attempts: 2, buffer: []string{"a", "b"}}, fsm.GetData())
+
+	// The timeout of the previous state is ignored
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, fsmTestConnected, fsm.GetState())
+	assert.Equal(t, 0, len(transitions))
+
+	fsm.Send("close")
+	<-fsm.Done()
+	assert.Equal(t, true, fsm.IsClosed())
+}
@@ -0,0 +1,246 @@
+package fpgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// PersistenceJournal[E, S] Journal of events(E) & snapshots(S) for persistent Actors
+type PersistenceJournal[E any, S any] interface {
+	// Append Append events in order, returns the sequence number of the last one
+	Append(persistenceID string, events ...E) (uint64, error)
+	// Replay Replay the events whose sequence numbers are greater than fromSequenceNr in order
+	Replay(persistenceID string, fromSequenceNr uint64, fn func(sequenceNr uint64, event E)) error
+	// SaveSnapshot Save the state as of the sequence number
+	SaveSnapshot(persistenceID string, sequenceNr uint64, state S) error
+	// LoadSnapshot Load the latest snapshot (ok is false if there's none)
+	LoadSnapshot(persistenceID string) (sequenceNr uint64, state S, ok bool, err error)
+}
+
+// PersistenceMemoryJournalDef[E, S] In-memory PersistenceJournal (for tests)
+type PersistenceMemoryJournalDef[E any, S any] struct {
+	events    map[string][]E
+	snapshots map[string]persistenceSnapshot[S]
+	journalM  sync.RWMutex
+}
+
+type persistenceSnapshot[S any] struct {
+	SequenceNr uint64 `json:"sequenceNr"`
+	State      S      `json:"state"`
+}
+
+type persistenceEvent[E any] struct {
+	SequenceNr uint64 `json:"sequenceNr"`
+	Event      E      `json:"event"`
+}
+
+// PersistenceMemoryJournalNewGenerics New an in-memory PersistenceJournal
+func PersistenceMemoryJournalNewGenerics[E any, S any]() *PersistenceMemoryJournalDef[E, S] {
+	return &PersistenceMemoryJournalDef[E, S]{
+		events:    map[string][]E{},
+		snapshots: map[string]persistenceSnapshot[S]{},
+	}
+}
+
+// Append Append events in order, returns the sequence number of the last one
+func (journalSelf *PersistenceMemoryJournalDef[E, S]) Append(persistenceID string, events ...E) (uint64, error) {
+	journalSelf.journalM.Lock()
+	defer journalSelf.journalM.Unlock()
+	journalSelf.events[persistenceID] = append(journalSelf.events[persistenceID], events...)
+	return uint64(len(journalSelf.events[persistenceID])), nil
+}
+
+// Replay Replay the events whose sequence numbers are greater than fromSequenceNr in order
+func (journalSelf *PersistenceMemoryJournalDef[E, S]) Replay(persistenceID string, fromSequenceNr uint64, fn func(sequenceNr uint64, event E)) error {
+	journalSelf.journalM.RLock()
+	events := journalSelf.events[persistenceID]
+	journalSelf.journalM.RUnlock()
+
+	for i := fromSequenceNr; i < uint64(len(events)); i++ {
+		fn(i+1, events[i])
+	}
+	return nil
+}
+
+// SaveSnapshot Save the state as of the sequence number
+func (journalSelf *PersistenceMemoryJournalDef[E, S]) SaveSnapshot(persistenceID string, sequenceNr uint64, state S) error {
+	journalSelf.journalM.Lock()
+	defer journalSelf.journalM.Unlock()
+	journalSelf.snapshots[persistenceID] = persistenceSnapshot[S]{SequenceNr: sequenceNr, State: state}
+	return nil
+}
+
+// LoadSnapshot Load the latest snapshot (ok is false if there's none)
+func (journalSelf *PersistenceMemoryJournalDef[E, S]) LoadSnapshot(persistenceID string) (uint64, S, bool, error) {
+	journalSelf.journalM.RLock()
+	defer journalSelf.journalM.RUnlock()
+	snapshot, ok := journalSelf.snapshots[persistenceID]
+	return snapshot.SequenceNr, snapshot.State, ok, nil
+}
+
+// PersistenceFileJournalDef[E, S] File-based append-only PersistenceJournal (JSON lines)
+//
+// Events are appended to <dir>/<persistenceID>.events, and the latest snapshot is kept in <dir>/<persistenceID>.snapshot.
+type PersistenceFileJournalDef[E any, S any] struct {
+	dir string
+
+	lastSequenceNrs map[string]uint64
+	journalM        sync.Mutex
+}
+
+// PersistenceFileJournalNewGenerics New a file-based PersistenceJournal in the directory
+func PersistenceFileJournalNewGenerics[E any, S any](dir string) (*PersistenceFileJournalDef[E, S], error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PersistenceFileJournalDef[E, S]{
+		dir:             dir,
+		lastSequenceNrs: map[string]uint64{},
+	}, nil
+}
+
+// Append Append events in order, returns the sequence number of the last one
+func (journalSelf *PersistenceFileJournalDef[E, S]) Append(persistenceID string, events ...E) (uint64, error) {
+	journalSelf.journalM.Lock()
+	defer journalSelf.journalM.Unlock()
+
+	sequenceNr, err := journalSelf.lastSequenceNr(persistenceID)
+	if err != nil {
+		return 0, err
+	}
+
+	var lines []byte
+	for _, event := range events {
+		line, err := json.Marshal(persistenceEvent[E]{SequenceNr: sequenceNr + 1, Event: event})
+		if err != nil {
+			return sequenceNr, err
+		}
+		lines = append(append(lines, line...), '\n')
+		sequenceNr++
+	}
+
+	file, err := os.OpenFile(journalSelf.filePath(persistenceID, ".events"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	_, err = file.Write(lines)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		// The sequence number should be reloaded from the file
+		delete(journalSelf.lastSequenceNrs, persistenceID)
+		return 0, err
+	}
+
+	journalSelf.lastSequenceNrs[persistenceID] = sequenceNr
+	return sequenceNr, nil
+}
+
+// Replay Replay the events whose sequence numbers are greater than fromSequenceNr in order
+func (journalSelf *PersistenceFileJournalDef[E, S]) Replay(persistenceID string, fromSequenceNr uint64, fn func(sequenceNr uint64, event E)) error {
+	_, err := journalSelf.readEvents(persistenceID, func(entry persistenceEvent[E]) {
+		if entry.SequenceNr > fromSequenceNr {
+			fn(entry.SequenceNr, entry.Event)
+		}
+	})
+	return err
+}
+
+// SaveSnapshot Save the state as of the sequence number
+func (journalSelf *PersistenceFileJournalDef[E, S]) SaveSnapshot(persistenceID string, sequenceNr uint64, state S) error {
+	data, err := json.Marshal(persistenceSnapshot[S]{SequenceNr: sequenceNr, State: state})
+	if err != nil {
+		return err
+	}
+
+	// Write then rename, the snapshot won't be broken by crashes
+	snapshotPath := journalSelf.filePath(persistenceID, ".snapshot")
+	err = os.WriteFile(snapshotPath+".tmp", data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(snapshotPath+".tmp", snapshotPath)
+}
+
+// LoadSnapshot Load the latest snapshot (ok is false if there's none)
+func (journalSelf *PersistenceFileJournalDef[E, S]) LoadSnapshot(persistenceID string) (uint64, S, bool, error) {
+	var snapshot persistenceSnapshot[S]
+
+	data, err := os.ReadFile(journalSelf.filePath(persistenceID, ".snapshot"))
+	if os.IsNotExist(err) {
+		return 0, snapshot.State, false, nil
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &snapshot)
+	}
+	if err != nil {
+		return 0, snapshot.State, false, err
+	}
+
+	return snapshot.SequenceNr, snapshot.State, true, nil
+}
+
+func (journalSelf *PersistenceFileJournalDef[E, S]) lastSequenceNr(persistenceID string) (uint64, error) {
+	if sequenceNr, ok := journalSelf.lastSequenceNrs[persistenceID]; ok {
+		return sequenceNr, nil
+	}
+
+	var sequenceNr uint64
+	size, err := journalSelf.readEvents(persistenceID, func(entry persistenceEvent[E]) {
+		sequenceNr = entry.SequenceNr
+	})
+	if err != nil {
+		return 0, err
+	}
+	// Truncate the partial line left by an interrupted write, the next write starts on a new line
+	err = os.Truncate(journalSelf.filePath(persistenceID, ".events"), size)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	journalSelf.lastSequenceNrs[persistenceID] = sequenceNr
+
+	return sequenceNr, nil
+}
+func (journalSelf *PersistenceFileJournalDef[E, S]) readEvents(persistenceID string, fn func(entry persistenceEvent[E])) (int64, error) {
+	file, err := os.Open(journalSelf.filePath(persistenceID, ".events"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var size int64
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// A partial line is left by an interrupted write, ignore it
+			return size, nil
+		}
+		if err != nil {
+			return size, err
+		}
+
+		var entry persistenceEvent[E]
+		err = json.Unmarshal(line, &entry)
+		if err != nil {
+			return size, err
+		}
+		fn(entry)
+		size += int64(len(line))
+	}
+}
+func (journalSelf *PersistenceFileJournalDef[E, S]) filePath(persistenceID string, ext string) string {
+	return filepath.Join(journalSelf.dir, url.PathEscape(persistenceID)+ext)
+}
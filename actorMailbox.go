@@ -0,0 +1,254 @@
+package fpgo
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MailboxOverflowPolicy What to do when a bounded Mailbox is full
+type MailboxOverflowPolicy int
+
+const (
+	// MailboxDropNewest Drop the incoming message quietly (reported by OnDropped, no error for the sender)
+	MailboxDropNewest MailboxOverflowPolicy = iota
+	// MailboxDropOldest Drop the oldest queued message to make room for the incoming one
+	MailboxDropOldest
+	// MailboxBlockWithTimeout Block the sender until there's room or BlockTimeout expires
+	MailboxBlockWithTimeout
+	// MailboxFailFast Reject the incoming message immediately by ErrActorMailboxFull
+	MailboxFailFast
+)
+
+// MailboxOption Options of the Mailbox of Actors
+type MailboxOption[T any] struct {
+	// Capacity Max queued messages (<= 0: unbounded)
+	Capacity int
+	// Overflow What to do when the Mailbox is full
+	Overflow MailboxOverflowPolicy
+	// BlockTimeout Timeout of MailboxBlockWithTimeout (0: block until there's room)
+	BlockTimeout time.Duration
+	// OnDropped Report the dropped/rejected messages
+	OnDropped func(message T, err error)
+
+	// Priority Order the queued messages by the priority (higher first, FIFO among the same priority; nil: FIFO)
+	//
+	// MailboxDropOldest drops the lowest-priority message instead of the oldest one (it could be the incoming one).
+	Priority func(message T) int
+	// IsControl Control messages(e.g. shutdown/health probes) are queued in another lane which is always drained first,
+	// and they're not limited by the Capacity
+	IsControl func(message T) bool
+}
+
+type actorMailbox[T any] struct {
+	option MailboxOption[T]
+
+	queue       []T
+	control     []T
+	queueM      sync.Mutex
+	isClosed    bool
+	isDiscarded bool
+
+	notEmpty chan struct{}
+	notFull  chan struct{}
+	closed   chan struct{}
+}
+
+func newActorMailbox[T any](option *MailboxOption[T]) *actorMailbox[T] {
+	mailbox := &actorMailbox[T]{
+		notEmpty: make(chan struct{}, 1),
+		notFull:  make(chan struct{}, 1),
+		closed:   make(chan struct{}),
+	}
+	if option != nil {
+		mailbox.option = *option
+	}
+
+	return mailbox
+}
+
+func (mailboxSelf *actorMailbox[T]) offer(message T) error {
+	var deadline <-chan time.Time
+	for {
+		mailboxSelf.queueM.Lock()
+		if mailboxSelf.isClosed {
+			mailboxSelf.queueM.Unlock()
+			return ErrActorClosed
+		}
+		if mailboxSelf.option.IsControl != nil && mailboxSelf.option.IsControl(message) {
+			mailboxSelf.control = append(mailboxSelf.control, message)
+			signal(mailboxSelf.notEmpty)
+			mailboxSelf.queueM.Unlock()
+			return nil
+		}
+
+		capacity := mailboxSelf.option.Capacity
+		if capacity <= 0 || len(mailboxSelf.queue) < capacity {
+			mailboxSelf.insert(message, false)
+			signal(mailboxSelf.notEmpty)
+			if capacity > 0 && len(mailboxSelf.queue) < capacity {
+				// Wake up the next blocked sender if there's still room
+				signal(mailboxSelf.notFull)
+			}
+			mailboxSelf.queueM.Unlock()
+			return nil
+		}
+
+		switch mailboxSelf.option.Overflow {
+		case MailboxDropOldest:
+			var dropped T
+			if mailboxSelf.option.Priority != nil {
+				// Drop the lowest-priority one (it could be the incoming one)
+				index := mailboxSelf.insert(message, false)
+				last := len(mailboxSelf.queue) - 1
+				dropped = mailboxSelf.queue[last]
+				mailboxSelf.queue = mailboxSelf.queue[:last]
+				if index == last {
+					mailboxSelf.queueM.Unlock()
+
+					// Dropped quietly like the others (reported by OnDropped/DeadLetters)
+					mailboxSelf.reportDropped(dropped, ErrActorMailboxFull)
+					return nil
+				}
+			} else {
+				dropped = mailboxSelf.queue[0]
+				mailboxSelf.queue = append(mailboxSelf.queue[1:], message)
+			}
+			signal(mailboxSelf.notEmpty)
+			mailboxSelf.queueM.Unlock()
+
+			mailboxSelf.reportDropped(dropped, ErrActorMailboxFull)
+			return nil
+		case MailboxBlockWithTimeout:
+			mailboxSelf.queueM.Unlock()
+
+			if deadline == nil && mailboxSelf.option.BlockTimeout > 0 {
+				deadline = time.After(mailboxSelf.option.BlockTimeout)
+			}
+			select {
+			case <-mailboxSelf.notFull:
+				continue
+			case <-mailboxSelf.closed:
+				continue
+			case <-deadline:
+				mailboxSelf.reportDropped(message, ErrActorMailboxTimeout)
+				return ErrActorMailboxTimeout
+			}
+		case MailboxFailFast:
+			mailboxSelf.queueM.Unlock()
+
+			mailboxSelf.reportDropped(message, ErrActorMailboxFull)
+			return ErrActorMailboxFull
+		default:
+			// MailboxDropNewest
+			mailboxSelf.queueM.Unlock()
+
+			mailboxSelf.reportDropped(message, ErrActorMailboxFull)
+			return nil
+		}
+	}
+}
+
+// prepend Put messages to the front regardless of the capacity
+func (mailboxSelf *actorMailbox[T]) prepend(messages ...T) {
+	mailboxSelf.queueM.Lock()
+	defer mailboxSelf.queueM.Unlock()
+	if mailboxSelf.isDiscarded {
+		return
+	}
+
+	if mailboxSelf.option.Priority != nil {
+		// In reverse order, each one goes before the queued ones of the same priority
+		for i := len(messages) - 1; i >= 0; i-- {
+			mailboxSelf.insert(messages[i], true)
+		}
+	} else {
+		mailboxSelf.queue = append(DuplicateSlice(messages), mailboxSelf.queue...)
+	}
+	signal(mailboxSelf.notEmpty)
+}
+
+// insert Insert the message by its priority (queueM should be locked), returns the index
+func (mailboxSelf *actorMailbox[T]) insert(message T, beforeSamePriority bool) int {
+	if mailboxSelf.option.Priority == nil {
+		mailboxSelf.queue = append(mailboxSelf.queue, message)
+		return len(mailboxSelf.queue) - 1
+	}
+
+	priority := mailboxSelf.option.Priority(message)
+	index := sort.Search(len(mailboxSelf.queue), func(i int) bool {
+		queued := mailboxSelf.option.Priority(mailboxSelf.queue[i])
+		if beforeSamePriority {
+			return queued <= priority
+		}
+		return queued < priority
+	})
+	var zero T
+	mailboxSelf.queue = append(mailboxSelf.queue, zero)
+	copy(mailboxSelf.queue[index+1:], mailboxSelf.queue[index:])
+	mailboxSelf.queue[index] = message
+
+	return index
+}
+
+func (mailboxSelf *actorMailbox[T]) poll() (T, bool) {
+	var message T
+
+	mailboxSelf.queueM.Lock()
+	defer mailboxSelf.queueM.Unlock()
+	if len(mailboxSelf.control) > 0 {
+		message = mailboxSelf.control[0]
+		mailboxSelf.control = mailboxSelf.control[1:]
+		return message, true
+	}
+	if len(mailboxSelf.queue) == 0 {
+		return message, false
+	}
+	message = mailboxSelf.queue[0]
+	mailboxSelf.queue = mailboxSelf.queue[1:]
+	if !mailboxSelf.isClosed {
+		signal(mailboxSelf.notFull)
+	}
+
+	return message, true
+}
+
+func (mailboxSelf *actorMailbox[T]) size() int {
+	mailboxSelf.queueM.Lock()
+	defer mailboxSelf.queueM.Unlock()
+	return len(mailboxSelf.control) + len(mailboxSelf.queue)
+}
+
+// close Reject new messages, and discard the queued ones if discard (the discarded ones are returned)
+func (mailboxSelf *actorMailbox[T]) close(discard bool) []T {
+	mailboxSelf.queueM.Lock()
+	defer mailboxSelf.queueM.Unlock()
+	if mailboxSelf.isClosed {
+		return nil
+	}
+	mailboxSelf.isClosed = true
+	var discarded []T
+	if discard {
+		mailboxSelf.isDiscarded = true
+		discarded = append(mailboxSelf.control, mailboxSelf.queue...)
+		mailboxSelf.control = nil
+		mailboxSelf.queue = nil
+	}
+	close(mailboxSelf.closed)
+
+	return discarded
+}
+
+func (mailboxSelf *actorMailbox[T]) reportDropped(message T, err error) {
+	if mailboxSelf.option.OnDropped != nil {
+		mailboxSelf.option.OnDropped(message, err)
+	}
+}
+
+// signal Notify the waiting side without blocking
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,171 @@
+package fpgo
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorMailboxOverflow(t *testing.T) {
+	var err error
+
+	for _, policy := range []MailboxOverflowPolicy{MailboxDropNewest, MailboxDropOldest, MailboxFailFast, MailboxBlockWithTimeout} {
+		var droppedM sync.Mutex
+		var dropped []int
+		var received []int
+		gate := make(chan struct{})
+		started := make(chan struct{})
+		done := make(chan struct{})
+
+		actorRoot := ActorNewWithOptionGenerics(func(self *ActorDef[int], input int) {
+			if input == 0 {
+				// Hold the Actor until the Mailbox is full
+				close(started)
+				<-gate
+				return
+			}
+			received = append(received, input)
+			if len(received) == 2 {
+				close(done)
+			}
+		}, &ActorOption[int]{
+			Mailbox: &MailboxOption[int]{
+				Capacity:     2,
+				Overflow:     policy,
+				BlockTimeout: 10 * time.Millisecond,
+				OnDropped: func(message int, err error) {
+					droppedM.Lock()
+					dropped = append(dropped, message)
+					droppedM.Unlock()
+				},
+			},
+		})
+		actorRoot.Send(0)
+		<-started
+
+		assert.Equal(t, nil, actorRoot.TrySend(1))
+		assert.Equal(t, nil, actorRoot.TrySend(2))
+		err = actorRoot.TrySend(3)
+		switch policy {
+		case MailboxDropNewest:
+			assert.Equal(t, nil, err)
+			assert.Equal(t, []int{3}, dropped)
+		case MailboxFailFast:
+			assert.Equal(t, ErrActorMailboxFull, err)
+			assert.Equal(t, []int{3}, dropped)
+		case MailboxDropOldest:
+			assert.Equal(t, nil, err)
+			assert.Equal(t, []int{1}, dropped)
+		case MailboxBlockWithTimeout:
+			assert.Equal(t, ErrActorMailboxTimeout, err)
+			assert.Equal(t, []int{3}, dropped)
+		}
+
+		close(gate)
+		<-done
+		switch policy {
+		case MailboxDropOldest:
+			assert.Equal(t, []int{2, 3}, received)
+		default:
+			assert.Equal(t, []int{1, 2}, received)
+		}
+		actorRoot.Close()
+		assert.Equal(t, ErrActorClosed, actorRoot.TrySend(1))
+	}
+}
+
+func TestActorMailboxBlock(t *testing.T) {
+	var actual int
+	gate := make(chan struct{})
+	done := make(chan struct{})
+
+	actorRoot := ActorNewWithOptionGenerics(func(self *ActorDef[int], input int) {
+		if input == 0 {
+			<-gate
+			return
+		}
+		actual += input
+		if actual == 6 {
+			close(done)
+		}
+	}, &ActorOption[int]{
+		Mailbox: &MailboxOption[int]{
+			Capacity: 1,
+			Overflow: MailboxBlockWithTimeout,
+		},
+	})
+	actorRoot.Send(0)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(gate)
+	}()
+	// Blocked until the Actor takes messages
+	actorRoot.Send(1)
+	actorRoot.Send(2)
+	actorRoot.Send(3)
+	<-done
+	assert.Equal(t, 6, actual)
+}
+
+func TestActorMailboxPriority(t *testing.T) {
+	var received []string
+	var dropped []string
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan struct{})
+
+	actorRoot := ActorNewWithOptionGenerics(func(self *ActorDef[string], input string) {
+		if input == "hold" {
+			close(started)
+			<-gate
+			return
+		}
+		received = append(received, input)
+		if len(received) == 6 {
+			close(done)
+		}
+	}, &ActorOption[string]{
+		Mailbox: &MailboxOption[string]{
+			Capacity: 4,
+			Overflow: MailboxDropOldest,
+			OnDropped: func(message string, err error) {
+				dropped = append(dropped, message)
+			},
+			Priority: func(message string) int {
+				switch {
+				case strings.HasPrefix(message, "high:"):
+					return 2
+				case strings.HasPrefix(message, "low:"):
+					return 0
+				default:
+					return 1
+				}
+			},
+			IsControl: func(message string) bool {
+				return strings.HasPrefix(message, "ctl:")
+			},
+		},
+	})
+	actorRoot.Send("hold")
+	<-started
+
+	for _, message := range []string{"low:1", "mid:1", "high:1", "ctl:1", "mid:2", "ctl:2"} {
+		assert.Equal(t, nil, actorRoot.TrySend(message))
+	}
+	// Control messages are not limited by the Capacity
+	assert.Equal(t, 6, actorRoot.GetMailboxSize())
+	// The lowest-priority one is dropped
+	assert.Equal(t, nil, actorRoot.TrySend("high:2"))
+	// The incoming one is dropped quietly too
+	assert.Equal(t, nil, actorRoot.TrySend("low:2"))
+	assert.Equal(t, []string{"low:1", "low:2"}, dropped)
+
+	close(gate)
+	<-done
+	assert.Equal(t, []string{"ctl:1", "ctl:2", "high:1", "high:2", "mid:1", "mid:2"}, received)
+	actorRoot.Stop()
+}
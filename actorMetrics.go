@@ -0,0 +1,240 @@
+package fpgo
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var actorDefaultLatencyBuckets = []time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// ActorDefaultLatencyBuckets Get the default upper bounds of buckets of processing latency histograms (the last bucket is unbounded)
+//
+// Use ActorOption.LatencyBuckets for others.
+func ActorDefaultLatencyBuckets() []time.Duration {
+	return DuplicateSlice(actorDefaultLatencyBuckets)
+}
+
+type actorMetrics struct {
+	processed     uint64
+	failures      uint64
+	restarts      uint64
+	latencySum    int64
+	latencyCounts []uint64
+	// latencyBuckets A copy of ActorOption.LatencyBuckets (sorted ascending)
+	latencyBuckets []time.Duration
+}
+
+// ActorMetricsSnapshot Snapshot of runtime metrics of an Actor & its children
+type ActorMetricsSnapshot struct {
+	ID          uint64
+	Name        string
+	Path        string
+	IsClosed    bool
+	MailboxSize int
+	// Processed The number of received messages
+	Processed uint64
+	// Failures The number of panics of the effect
+	Failures uint64
+	// Restarts The number of restarts by the supervisor
+	Restarts uint64
+	Latency  ActorLatencySnapshot
+	// Children Snapshots of children (sorted by paths)
+	Children []ActorMetricsSnapshot
+}
+
+// ActorLatencySnapshot Histogram of processing latency
+type ActorLatencySnapshot struct {
+	// Buckets Upper bounds of buckets (the last bucket of Counts is unbounded)
+	Buckets []time.Duration
+	Counts  []uint64
+	Count   uint64
+	Sum     time.Duration
+}
+
+// GetMetrics Get the snapshot of metrics of the Actor & its children
+func (actorSelf *ActorDef[T]) GetMetrics() ActorMetricsSnapshot {
+	metrics := &actorSelf.metrics
+	snapshot := ActorMetricsSnapshot{
+		ID:          actorSelf.id,
+		Name:        actorSelf.name,
+		Path:        actorSelf.path,
+		IsClosed:    actorSelf.IsClosed(),
+		MailboxSize: actorSelf.GetMailboxSize(),
+		Processed:   atomic.LoadUint64(&metrics.processed),
+		Failures:    atomic.LoadUint64(&metrics.failures),
+		Restarts:    atomic.LoadUint64(&metrics.restarts),
+		Latency: ActorLatencySnapshot{
+			Buckets: DuplicateSlice(metrics.latencyBuckets),
+			Counts:  make([]uint64, len(metrics.latencyCounts)),
+			Sum:     time.Duration(atomic.LoadInt64(&metrics.latencySum)),
+		},
+	}
+	for i := range metrics.latencyCounts {
+		snapshot.Latency.Counts[i] = atomic.LoadUint64(&metrics.latencyCounts[i])
+		snapshot.Latency.Count += snapshot.Latency.Counts[i]
+	}
+
+	for _, child := range actorSelf.GetChildren() {
+		snapshot.Children = append(snapshot.Children, child.GetMetrics())
+	}
+	sort.Slice(snapshot.Children, func(i, j int) bool {
+		return snapshot.Children[i].Path < snapshot.Children[j].Path
+	})
+
+	return snapshot
+}
+
+// GetMetrics Get snapshots of metrics of top-level Actors & their children (sorted by paths)
+func (systemSelf *ActorSystemDef) GetMetrics() []ActorMetricsSnapshot {
+	var snapshots []ActorMetricsSnapshot
+	systemSelf.registryM.RLock()
+	for actorPath, actor := range systemSelf.registry {
+		withMetrics, ok := actor.(interface{ GetMetrics() ActorMetricsSnapshot })
+		if ok && path.Dir(actorPath) == ActorPathUser {
+			snapshots = append(snapshots, withMetrics.GetMetrics())
+		}
+	}
+	systemSelf.registryM.RUnlock()
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Path < snapshots[j].Path
+	})
+	return snapshots
+}
+
+// Mean Get the mean latency
+func (latencySelf ActorLatencySnapshot) Mean() time.Duration {
+	if latencySelf.Count == 0 {
+		return 0
+	}
+
+	return latencySelf.Sum / time.Duration(latencySelf.Count)
+}
+
+// Quantile Get the upper bound of the bucket containing the quantile(0~1) (-1: in the unbounded bucket)
+func (latencySelf ActorLatencySnapshot) Quantile(q float64) time.Duration {
+	if latencySelf.Count == 0 {
+		return 0
+	}
+
+	rank := uint64(q * float64(latencySelf.Count))
+	var accumulated uint64
+	for i, count := range latencySelf.Counts {
+		accumulated += count
+		if accumulated > rank || accumulated == latencySelf.Count {
+			if i < len(latencySelf.Buckets) {
+				return latencySelf.Buckets[i]
+			}
+			break
+		}
+	}
+	return -1
+}
+
+// String Dump the Actor tree with metrics (indented by depths)
+func (snapshotSelf ActorMetricsSnapshot) String() string {
+	var builder strings.Builder
+	snapshotSelf.dump(&builder, 0)
+	return builder.String()
+}
+func (snapshotSelf ActorMetricsSnapshot) dump(builder *strings.Builder, depth int) {
+	fmt.Fprintf(builder, "%s%s mailbox=%d processed=%d failures=%d restarts=%d latency_mean=%v latency_p99<=%v",
+		strings.Repeat("  ", depth), snapshotSelf.Path,
+		snapshotSelf.MailboxSize, snapshotSelf.Processed, snapshotSelf.Failures, snapshotSelf.Restarts,
+		snapshotSelf.Latency.Mean(), snapshotSelf.Latency.Quantile(0.99))
+	if snapshotSelf.IsClosed {
+		builder.WriteString(" closed")
+	}
+	builder.WriteString("\n")
+
+	for _, child := range snapshotSelf.Children {
+		child.dump(builder, depth+1)
+	}
+}
+
+// ActorMetricsExportText Export the snapshots(& their children) in the Prometheus text format
+func ActorMetricsExportText(writer io.Writer, snapshots ...ActorMetricsSnapshot) error {
+	var flatten []ActorMetricsSnapshot
+	var visit func(snapshot ActorMetricsSnapshot)
+	visit = func(snapshot ActorMetricsSnapshot) {
+		flatten = append(flatten, snapshot)
+		for _, child := range snapshot.Children {
+			visit(child)
+		}
+	}
+	for _, snapshot := range snapshots {
+		visit(snapshot)
+	}
+
+	var builder strings.Builder
+	writeMetric := func(name string, kind string, help string, value func(snapshot ActorMetricsSnapshot) string) {
+		fmt.Fprintf(&builder, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, snapshot := range flatten {
+			fmt.Fprintf(&builder, "%s{path=%q} %s\n", name, snapshot.Path, value(snapshot))
+		}
+	}
+	writeMetric("fpgo_actor_mailbox_size", "gauge", "Queued messages of the Actor", func(snapshot ActorMetricsSnapshot) string {
+		return strconv.Itoa(snapshot.MailboxSize)
+	})
+	writeMetric("fpgo_actor_processed_total", "counter", "Received messages of the Actor", func(snapshot ActorMetricsSnapshot) string {
+		return strconv.FormatUint(snapshot.Processed, 10)
+	})
+	writeMetric("fpgo_actor_failures_total", "counter", "Panics of the effect of the Actor", func(snapshot ActorMetricsSnapshot) string {
+		return strconv.FormatUint(snapshot.Failures, 10)
+	})
+	writeMetric("fpgo_actor_restarts_total", "counter", "Restarts of the Actor by the supervisor", func(snapshot ActorMetricsSnapshot) string {
+		return strconv.FormatUint(snapshot.Restarts, 10)
+	})
+
+	name := "fpgo_actor_processing_seconds"
+	fmt.Fprintf(&builder, "# HELP %s Processing latency of messages of the Actor\n# TYPE %s histogram\n", name, name)
+	for _, snapshot := range flatten {
+		latency := snapshot.Latency
+		var accumulated uint64
+		for i, count := range latency.Counts {
+			accumulated += count
+			le := "+Inf"
+			if i < len(latency.Buckets) {
+				le = strconv.FormatFloat(latency.Buckets[i].Seconds(), 'g', -1, 64)
+			}
+			fmt.Fprintf(&builder, "%s_bucket{path=%q,le=%q} %d\n", name, snapshot.Path, le, accumulated)
+		}
+		fmt.Fprintf(&builder, "%s_sum{path=%q} %s\n", name, snapshot.Path, strconv.FormatFloat(latency.Sum.Seconds(), 'g', -1, 64))
+		fmt.Fprintf(&builder, "%s_count{path=%q} %d\n", name, snapshot.Path, latency.Count)
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+func actorMetricsNew(buckets []time.Duration) actorMetrics {
+	if buckets == nil {
+		buckets = actorDefaultLatencyBuckets
+	}
+	buckets = DuplicateSlice(buckets)
+	return actorMetrics{latencyBuckets: buckets, latencyCounts: make([]uint64, len(buckets)+1)}
+}
+
+// recordLatency Record the processing latency of a message
+func (metricsSelf *actorMetrics) recordLatency(latency time.Duration) {
+	atomic.AddUint64(&metricsSelf.processed, 1)
+	atomic.AddInt64(&metricsSelf.latencySum, int64(latency))
+
+	index := sort.Search(len(metricsSelf.latencyBuckets), func(i int) bool {
+		return latency <= metricsSelf.latencyBuckets[i]
+	})
+	atomic.AddUint64(&metricsSelf.latencyCounts[index], 1)
+}
@@ -0,0 +1,80 @@
+package fpgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorMetrics(t *testing.T) {
+	system := ActorSystem.New("metrics")
+	option := &ActorOption[interface{}]{
+		Dispatcher: ActorDispatcherCallingThread,
+		Supervisor: SupervisorStrategy.OneForOne(-1, 0, nil),
+	}
+	parent, _ := ActorOfGenerics(system, "parent", func(self *ActorDef[interface{}], input interface{}) {}, option)
+	worker, _ := parent.SpawnNamed("worker", func(self *ActorDef[interface{}], input interface{}) {
+		if input == "boom" {
+			panic(input)
+		}
+		if input == "slow" {
+			time.Sleep(2 * time.Millisecond)
+		}
+	}, option)
+	worker.Send(1)
+	worker.Send("slow")
+	worker.Send("boom")
+	parent.Send(1)
+
+	// Snapshots
+	snapshot := worker.GetMetrics()
+	assert.Equal(t, "/user/parent/worker", snapshot.Path)
+	assert.Equal(t, uint64(3), snapshot.Processed)
+	assert.Equal(t, uint64(1), snapshot.Failures)
+	assert.Equal(t, uint64(1), snapshot.Restarts)
+	assert.Equal(t, 0, snapshot.MailboxSize)
+	assert.Equal(t, uint64(3), snapshot.Latency.Count)
+	assert.Equal(t, len(ActorDefaultLatencyBuckets())+1, len(snapshot.Latency.Counts))
+	assert.Equal(t, true, snapshot.Latency.Sum >= 2*time.Millisecond)
+	// The slow one is in the bucket of 10ms or a later one (e.g. under load)
+	p99 := snapshot.Latency.Quantile(0.99)
+	assert.Equal(t, true, p99 >= 10*time.Millisecond || p99 == -1)
+	assert.Equal(t, time.Duration(0), ActorLatencySnapshot{}.Mean())
+
+	// Tree
+	snapshots := system.GetMetrics()
+	assert.Equal(t, 1, len(snapshots))
+	assert.Equal(t, "/user/parent", snapshots[0].Path)
+	assert.Equal(t, uint64(1), snapshots[0].Processed)
+	assert.Equal(t, 1, len(snapshots[0].Children))
+	assert.Equal(t, "/user/parent/worker", snapshots[0].Children[0].Path)
+	lines := strings.Split(strings.TrimSpace(snapshots[0].String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, true, strings.HasPrefix(lines[0], "/user/parent mailbox=0 processed=1"))
+	assert.Equal(t, true, strings.HasPrefix(lines[1], "  /user/parent/worker mailbox=0 processed=3 failures=1 restarts=1"))
+
+	// Exporter
+	var builder strings.Builder
+	assert.Equal(t, nil, ActorMetricsExportText(&builder, snapshots...))
+	text := builder.String()
+	assert.Equal(t, true, strings.Contains(text, "# TYPE fpgo_actor_processed_total counter\n"))
+	assert.Equal(t, true, strings.Contains(text, `fpgo_actor_processed_total{path="/user/parent/worker"} 3`))
+	assert.Equal(t, true, strings.Contains(text, `fpgo_actor_restarts_total{path="/user/parent/worker"} 1`))
+	assert.Equal(t, true, strings.Contains(text, `fpgo_actor_processing_seconds_bucket{path="/user/parent/worker",le="+Inf"} 3`))
+	assert.Equal(t, true, strings.Contains(text, `fpgo_actor_processing_seconds_count{path="/user/parent"} 1`))
+
+	// Buckets by ActorOption
+	custom := ActorNewWithOptionGenerics(func(self *ActorDef[interface{}], input interface{}) {}, &ActorOption[interface{}]{
+		Dispatcher:     ActorDispatcherCallingThread,
+		LatencyBuckets: []time.Duration{time.Second},
+	})
+	custom.Send(1)
+	assert.Equal(t, []time.Duration{time.Second}, custom.GetMetrics().Latency.Buckets)
+	assert.Equal(t, []uint64{1, 0}, custom.GetMetrics().Latency.Counts)
+	assert.Equal(t, ActorDefaultLatencyBuckets(), worker.GetMetrics().Latency.Buckets)
+
+	system.Shutdown()
+	assert.Equal(t, true, worker.GetMetrics().IsClosed)
+}
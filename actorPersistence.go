@@ -0,0 +1,164 @@
+package fpgo
+
+// PersistentActorOption Options for creating persistent Actors
+type PersistentActorOption[T any] struct {
+	// Actor Options of the underlying Actor
+	Actor *ActorOption[T]
+	// SnapshotEvery Save a snapshot every N persisted events (0: never automatically)
+	SnapshotEvery uint64
+	// OnSnapshotFailure Report errors of automatic snapshots (the events are persisted already, Persist() doesn't fail by them)
+	OnSnapshotFailure func(err error)
+}
+
+// PersistentActorDef[T, E, S] Event-sourced Actor inspired by Akka Persistence
+//
+// Commands(T) produce events(E) which are appended to the journal and applied to the state(S),
+// the state is recovered by the latest snapshot & replaying events on start.
+type PersistentActorDef[T any, E any, S any] struct {
+	*ActorDef[T]
+
+	persistenceID  string
+	journal        PersistenceJournal[E, S]
+	eventHandler   func(S, E) S
+	state          S
+	initState      S
+	sequenceNr     uint64
+	snapshotEvery  uint64
+	snapshotNr     uint64
+	onSnapshotFail func(err error)
+	recoveryFailed error
+}
+
+// PersistentActorNewGenerics New a persistent Actor
+//
+// eventHandler applies an event to the state, commandHandler handles commands by calling Persist().
+// The Actor is stopped if the recovery failed (see GetRecoveryError()), and it's recovered again when restarted by the Supervisor.
+func PersistentActorNewGenerics[T any, E any, S any](persistenceID string, journal PersistenceJournal[E, S], initState S, eventHandler func(S, E) S, commandHandler func(*PersistentActorDef[T, E, S], T), option *PersistentActorOption[T]) *PersistentActorDef[T, E, S] {
+	newOne := &PersistentActorDef[T, E, S]{
+		persistenceID: persistenceID,
+		journal:       journal,
+		eventHandler:  eventHandler,
+		state:         initState,
+		initState:     initState,
+	}
+
+	var actorOption ActorOption[T]
+	if option != nil {
+		newOne.snapshotEvery = option.SnapshotEvery
+		newOne.onSnapshotFail = option.OnSnapshotFailure
+		if option.Actor != nil {
+			actorOption = *option.Actor
+		}
+	}
+	onRestart := actorOption.OnRestart
+	actorOption.OnRestart = func(self *ActorDef[T]) {
+		newOne.state = newOne.initState
+		newOne.sequenceNr = 0
+		newOne.snapshotNr = 0
+		if onRestart != nil {
+			onRestart(self)
+		}
+	}
+	preStart := actorOption.PreStart
+	actorOption.PreStart = func(self *ActorDef[T]) {
+		err := newOne.recover()
+		if err != nil {
+			newOne.recoveryFailed = err
+			self.stopByFailure(err)
+			return
+		}
+		if preStart != nil {
+			preStart(self)
+		}
+	}
+
+	newOne.ActorDef = actorNew(func(self *ActorDef[T], command T) {
+		commandHandler(newOne, command)
+	}, nil, map[string]interface{}{}, &actorOption)
+	newOne.ActorDef.start()
+
+	return newOne
+}
+
+// Persist Append the events to the journal and apply them to the state
+//
+// It should be called in the Actor goroutine (e.g. in the commandHandler).
+// The error is of appending only (nothing is persisted then),
+// errors of automatic snapshots are reported to PersistentActorOption.OnSnapshotFailure.
+func (actorSelf *PersistentActorDef[T, E, S]) Persist(events ...E) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	sequenceNr, err := actorSelf.journal.Append(actorSelf.persistenceID, events...)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		actorSelf.state = actorSelf.eventHandler(actorSelf.state, event)
+	}
+	actorSelf.sequenceNr = sequenceNr
+
+	if actorSelf.snapshotEvery > 0 && actorSelf.sequenceNr-actorSelf.snapshotNr >= actorSelf.snapshotEvery {
+		// It's retried by the next Persist()
+		err = actorSelf.SaveSnapshot()
+		if err != nil && actorSelf.onSnapshotFail != nil {
+			actorSelf.onSnapshotFail(err)
+		}
+	}
+	return nil
+}
+
+// SaveSnapshot Save the current state as a snapshot
+//
+// It should be called in the Actor goroutine (e.g. in the commandHandler).
+func (actorSelf *PersistentActorDef[T, E, S]) SaveSnapshot() error {
+	err := actorSelf.journal.SaveSnapshot(actorSelf.persistenceID, actorSelf.sequenceNr, actorSelf.state)
+	if err != nil {
+		return err
+	}
+	actorSelf.snapshotNr = actorSelf.sequenceNr
+
+	return nil
+}
+
+// GetState Get the current state
+//
+// It should be called in the Actor goroutine (e.g. in the commandHandler).
+func (actorSelf *PersistentActorDef[T, E, S]) GetState() S {
+	return actorSelf.state
+}
+
+// GetSequenceNr Get the sequence number of the last persisted event
+//
+// It should be called in the Actor goroutine (e.g. in the commandHandler).
+func (actorSelf *PersistentActorDef[T, E, S]) GetSequenceNr() uint64 {
+	return actorSelf.sequenceNr
+}
+
+// GetPersistenceID Get the persistence ID
+func (actorSelf *PersistentActorDef[T, E, S]) GetPersistenceID() string {
+	return actorSelf.persistenceID
+}
+
+// GetRecoveryError Get the error of the recovery (valid after the Actor terminated)
+func (actorSelf *PersistentActorDef[T, E, S]) GetRecoveryError() error {
+	return actorSelf.recoveryFailed
+}
+
+func (actorSelf *PersistentActorDef[T, E, S]) recover() error {
+	sequenceNr, state, ok, err := actorSelf.journal.LoadSnapshot(actorSelf.persistenceID)
+	if err != nil {
+		return err
+	}
+	if ok {
+		actorSelf.state = state
+		actorSelf.sequenceNr = sequenceNr
+		actorSelf.snapshotNr = sequenceNr
+	}
+
+	return actorSelf.journal.Replay(actorSelf.persistenceID, actorSelf.sequenceNr, func(sequenceNr uint64, event E) {
+		actorSelf.state = actorSelf.eventHandler(actorSelf.state, event)
+		actorSelf.sequenceNr = sequenceNr
+	})
+}
@@ -0,0 +1,174 @@
+package fpgo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorPersistence(t *testing.T) {
+	fileJournal, err := PersistenceFileJournalNewGenerics[int, int](t.TempDir())
+	assert.Equal(t, nil, err)
+
+	for _, journal := range []PersistenceJournal[int, int]{
+		PersistenceMemoryJournalNewGenerics[int, int](),
+		fileJournal,
+	} {
+		var applied int
+		newCounter := func() *PersistentActorDef[interface{}, int, int] {
+			applied = 0
+			return PersistentActorNewGenerics(
+				"counter/1",
+				journal,
+				0,
+				func(state int, event int) int {
+					applied++
+					return state + event
+				},
+				func(self *PersistentActorDef[interface{}, int, int], command interface{}) {
+					switch val := command.(type) {
+					case int:
+						self.Persist(val)
+					case *AskDef[interface{}, int]:
+						val.Reply(self.GetState())
+					}
+				},
+				&PersistentActorOption[interface{}]{SnapshotEvery: 2},
+			)
+		}
+		getState := func(actor *PersistentActorDef[interface{}, int, int]) int {
+			state, _ := AskNewGenerics[interface{}, int](nil).AskOnce(actor, nil)
+			return state
+		}
+
+		// Persist
+		counter := newCounter()
+		counter.Send(1)
+		counter.Send(2)
+		counter.Send(3)
+		assert.Equal(t, 6, getState(counter))
+		counter.Stop()
+		assert.Equal(t, nil, counter.AwaitTermination(context.Background()))
+
+		// Recover by the snapshot(1+2) & replaying events after it(3)
+		counter = newCounter()
+		assert.Equal(t, 6, getState(counter))
+		assert.Equal(t, 1, applied)
+		sequenceNr, snapshot, ok, _ := journal.LoadSnapshot("counter/1")
+		assert.Equal(t, true, ok)
+		assert.Equal(t, uint64(2), sequenceNr)
+		assert.Equal(t, 3, snapshot)
+
+		counter.Send(4)
+		assert.Equal(t, 10, getState(counter))
+		counter.Stop()
+		<-counter.Done()
+
+		// All events are kept in order
+		var events []int
+		journal.Replay("counter/1", 0, func(sequenceNr uint64, event int) {
+			events = append(events, event)
+		})
+		assert.Equal(t, []int{1, 2, 3, 4}, events)
+	}
+
+	// Events are kept after reopening the file journal
+	fileJournal, _ = PersistenceFileJournalNewGenerics[int, int](fileJournal.dir)
+	lastSequenceNr, err := fileJournal.Append("counter/1", 5)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(5), lastSequenceNr)
+
+	// The partial line of an interrupted write is truncated before the next write
+	file, err := os.OpenFile(fileJournal.filePath("counter/1", ".events"), os.O_APPEND|os.O_WRONLY, 0644)
+	assert.Equal(t, nil, err)
+	file.WriteString(`{"SequenceNr":6,"Ev`)
+	file.Close()
+	fileJournal, _ = PersistenceFileJournalNewGenerics[int, int](fileJournal.dir)
+	lastSequenceNr, err = fileJournal.Append("counter/1", 6)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, uint64(6), lastSequenceNr)
+	var events []int
+	err = fileJournal.Replay("counter/1", 0, func(sequenceNr uint64, event int) {
+		events = append(events, event)
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, events)
+}
+
+type persistenceSnapshotFailingJournal struct {
+	*PersistenceMemoryJournalDef[int, int]
+}
+
+func (journalSelf persistenceSnapshotFailingJournal) SaveSnapshot(persistenceID string, sequenceNr uint64, state int) error {
+	return fmt.Errorf("disk full")
+}
+
+func TestActorPersistenceSnapshotFailure(t *testing.T) {
+	journal := persistenceSnapshotFailingJournal{PersistenceMemoryJournalNewGenerics[int, int]()}
+	persisted := make(chan error, 1)
+	snapshotFailures := make(chan error, 1)
+	actor := PersistentActorNewGenerics("counter/1", PersistenceJournal[int, int](journal), 0,
+		func(state int, event int) int {
+			return state + event
+		},
+		func(self *PersistentActorDef[int, int, int], command int) {
+			persisted <- self.Persist(command)
+		},
+		&PersistentActorOption[int]{
+			SnapshotEvery: 1,
+			OnSnapshotFailure: func(err error) {
+				snapshotFailures <- err
+			},
+		},
+	)
+
+	// The event is persisted: Persist() doesn't fail by the snapshot (no double appending by retries)
+	actor.Send(5)
+	assert.Equal(t, fmt.Errorf("disk full"), <-snapshotFailures)
+	assert.Equal(t, nil, <-persisted)
+	var events []int
+	journal.Replay("counter/1", 0, func(sequenceNr uint64, event int) {
+		events = append(events, event)
+	})
+	assert.Equal(t, []int{5}, events)
+
+	actor.Stop()
+	<-actor.Done()
+}
+
+func TestActorPersistenceRestart(t *testing.T) {
+	journal := PersistenceMemoryJournalNewGenerics[int, int]()
+	states := make(chan int, 10)
+	actor := PersistentActorNewGenerics("counter/1", PersistenceJournal[int, int](journal), 0,
+		func(state int, event int) int {
+			return state + event
+		},
+		func(self *PersistentActorDef[int, int, int], command int) {
+			if command < 0 {
+				panic(command)
+			}
+			if command > 0 {
+				self.Persist(command)
+			}
+			states <- self.GetState()
+		},
+		&PersistentActorOption[int]{Actor: &ActorOption[int]{
+			Supervisor: SupervisorStrategy.OneForOne(-1, 0, nil),
+		}},
+	)
+	actor.Send(1)
+	actor.Send(2)
+	assert.Equal(t, 1, <-states)
+	assert.Equal(t, 3, <-states)
+
+	// Recovered again from the initial state (events are not applied twice)
+	actor.Send(-1)
+	actor.Send(0)
+	assert.Equal(t, 3, <-states)
+
+	actor.Stop()
+	<-actor.Done()
+}
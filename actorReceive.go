@@ -0,0 +1,34 @@
+package fpgo
+
+// ActorReceiveGenerics Make an Actor behavior(effect) dispatching messages by the Pattern list (by MatchFor)
+//
+// If the effect of the matching Pattern returns func(*ActorDef[T]) (e.g. made by ActorCaseGenerics), it's called with the Actor.
+// Unmatched messages go to unhandled instead of panics (nil: reported by ActorDef.Unhandled()).
+func ActorReceiveGenerics[T any](unhandled func(self *ActorDef[T], message T), patterns ...Pattern) func(*ActorDef[T], T) {
+	matching := DefPattern(patterns...)
+
+	return func(self *ActorDef[T], message T) {
+		result, ok := matching.TryMatchFor(message)
+		if !ok {
+			if unhandled != nil {
+				unhandled(self, message)
+			} else {
+				self.Unhandled(message)
+			}
+			return
+		}
+
+		if action, ok := result.(func(*ActorDef[T])); ok {
+			action(self)
+		}
+	}
+}
+
+// ActorCaseGenerics Make the effect of Patterns for ActorReceiveGenerics, fn is called with the Actor & the matching message
+func ActorCaseGenerics[T any](fn func(self *ActorDef[T], message interface{})) func(interface{}) interface{} {
+	return func(message interface{}) interface{} {
+		return func(self *ActorDef[T]) {
+			fn(self, message)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package fpgo
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorReceive(t *testing.T) {
+	var received []string
+	var unhandled []interface{}
+	done := make(chan struct{})
+
+	ping := DefProduct(reflect.String, reflect.Int)
+	actor := ActorNewGenerics(ActorReceiveGenerics(func(self *ActorDef[interface{}], message interface{}) {
+		unhandled = append(unhandled, message)
+		if message == "done" {
+			close(done)
+		}
+	},
+		InCaseOfEqual("hello", ActorCaseGenerics(func(self *ActorDef[interface{}], message interface{}) {
+			received = append(received, "equal:"+message.(string))
+		})),
+		InCaseOfSumType(ping, ActorCaseGenerics(func(self *ActorDef[interface{}], message interface{}) {
+			received = append(received, fmt.Sprintf("sum:%v", message.(CompData).objects))
+		})),
+		InCaseOfTypeGenerics[error](ActorCaseGenerics(func(self *ActorDef[interface{}], message interface{}) {
+			received = append(received, "error:"+message.(error).Error())
+		})),
+		InCaseOfKind(reflect.Int, func(message interface{}) interface{} {
+			// Plain effects work too
+			received = append(received, fmt.Sprintf("kind:%v", message))
+			return nil
+		}),
+	))
+
+	actor.Send("hello")
+	actor.Send(NewCompData(ping, "ping", 1))
+	actor.Send(fmt.Errorf("failed"))
+	actor.Send(3)
+	actor.Send(1.5)
+	actor.Send("done")
+	<-done
+
+	assert.Equal(t, []string{"equal:hello", "sum:[ping 1]", "error:failed", "kind:3"}, received)
+	assert.Equal(t, []interface{}{1.5, "done"}, unhandled)
+	actor.Stop()
+
+	// Reported as unhandled by default
+	system := ActorSystem.New("receive")
+	defer system.Shutdown()
+	unhandledCh := make(chan ActorDeadLetter, 1)
+	system.GetDeadLetters().Subscribe(func(deadLetter ActorDeadLetter) {
+		unhandledCh <- deadLetter
+	})
+	actor, _ = ActorOfGenerics(system, "", ActorReceiveGenerics[interface{}](nil,
+		InCaseOfKind(reflect.Int, func(message interface{}) interface{} {
+			return nil
+		}),
+	), nil)
+	actor.Send(1)
+	actor.Send("unknown")
+	deadLetter := <-unhandledCh
+	assert.Equal(t, "unknown", deadLetter.Message)
+	assert.Equal(t, ErrActorUnhandled, deadLetter.Reason)
+
+	// TryMatchFor won't panic
+	_, ok := DefPattern(InCaseOfTypeGenerics[error](nil)).TryMatchFor(1)
+	assert.Equal(t, false, ok)
+}
@@ -0,0 +1,289 @@
+package fpgo
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// RouterLogic How a Router picks routees for messages
+type RouterLogic int
+
+const (
+	// RouterRoundRobin Pick routees in turn
+	RouterRoundRobin RouterLogic = iota
+	// RouterRandom Pick a routee randomly
+	RouterRandom
+	// RouterBroadcast Send to all routees
+	RouterBroadcast
+	// RouterSmallestMailbox Pick the routee with the fewest queued messages
+	RouterSmallestMailbox
+	// RouterConsistentHash Pick the routee by the consistent hash of HashKey(message)
+	RouterConsistentHash
+)
+
+const (
+	// RouterDefaultVirtualNodes Default virtual nodes per routee of RouterConsistentHash
+	RouterDefaultVirtualNodes = 16
+)
+
+// RouterOption Options for creating Routers
+type RouterOption[T any] struct {
+	Logic RouterLogic
+	// Size Initial number of routees
+	Size int
+	// HashKey The key of the message for RouterConsistentHash
+	HashKey func(message T) string
+	// VirtualNodes Virtual nodes per routee for RouterConsistentHash (<= 0: RouterDefaultVirtualNodes)
+	VirtualNodes int
+	// Routee Options of routees
+	Routee *ActorOption[T]
+}
+
+// RouterDef[T] Router(pool) inspired by Akka, it looks like a single ActorHandle but fans messages out to routees
+//
+// Routees terminated unexpectedly(e.g. stopped by failures) are replaced automatically.
+// The Router is stopped when its parent terminated or routees can't be spawned by the parent.
+type RouterDef[T any] struct {
+	parent            *ActorDef[T]
+	parentTermination *Subscription[ActorTerminated]
+	effect            func(*ActorDef[T], T)
+	option            RouterOption[T]
+
+	isClosed AtomBool
+	routees  []*ActorDef[T]
+	ring     []routerRingNode
+	next     int
+	routeesM sync.Mutex
+}
+
+type routerRingNode struct {
+	hash  uint32
+	index int
+}
+
+// RouterNewGenerics New a Router with routees spawned by the parent (nil: routees have no parent)
+func RouterNewGenerics[T any](parent *ActorDef[T], effect func(*ActorDef[T], T), option RouterOption[T]) *RouterDef[T] {
+	if option.VirtualNodes <= 0 {
+		option.VirtualNodes = RouterDefaultVirtualNodes
+	}
+	newOne := &RouterDef[T]{
+		parent: parent,
+		effect: effect,
+		option: option,
+	}
+	if parent != nil {
+		newOne.routeesM.Lock()
+		newOne.parentTermination = parent.SubscribeTermination(func(ActorTerminated) {
+			// Not in the caller goroutine: it may hold routeesM
+			go newOne.Stop()
+		})
+		newOne.routeesM.Unlock()
+	}
+	newOne.Resize(option.Size)
+
+	return newOne
+}
+
+// Send Send a message to the routee(s) picked by RouterLogic
+func (routerSelf *RouterDef[T]) Send(message T) {
+	routerSelf.TrySend(message)
+}
+
+// TrySend Send a message to the routee(s) picked by RouterLogic, returns error if it's rejected
+func (routerSelf *RouterDef[T]) TrySend(message T) error {
+	if routerSelf.isClosed.Get() {
+		return ErrActorClosed
+	}
+
+	targets := routerSelf.pick(message)
+	if len(targets) == 0 {
+		return ErrActorClosed
+	}
+	var err error
+	for _, target := range targets {
+		if sendErr := target.TrySend(message); sendErr != nil {
+			err = sendErr
+		}
+	}
+	return err
+}
+
+// Resize Resize the pool of routees (the redundant ones are stopped)
+func (routerSelf *RouterDef[T]) Resize(size int) {
+	if size < 0 || routerSelf.isClosed.Get() {
+		return
+	}
+
+	var removed []*ActorDef[T]
+	var err error
+	routerSelf.routeesM.Lock()
+	for len(routerSelf.routees) < size {
+		var routee *ActorDef[T]
+		routee, err = routerSelf.spawnRoutee()
+		if err != nil {
+			break
+		}
+		routerSelf.routees = append(routerSelf.routees, routee)
+	}
+	if len(routerSelf.routees) > size {
+		removed = DuplicateSlice(routerSelf.routees[size:])
+		routerSelf.routees = routerSelf.routees[:size]
+	}
+	routerSelf.rebuildRing()
+	routerSelf.routeesM.Unlock()
+
+	for _, routee := range removed {
+		routee.Stop()
+	}
+	if err != nil {
+		routerSelf.Stop()
+	}
+}
+
+// GetRoutees Get the current routees
+func (routerSelf *RouterDef[T]) GetRoutees() []*ActorDef[T] {
+	routerSelf.routeesM.Lock()
+	defer routerSelf.routeesM.Unlock()
+	return DuplicateSlice(routerSelf.routees)
+}
+
+// Stop Stop the Router & all of its routees
+func (routerSelf *RouterDef[T]) Stop() {
+	routerSelf.isClosed.Set(true)
+
+	routerSelf.routeesM.Lock()
+	if routerSelf.parent != nil {
+		routerSelf.parent.UnsubscribeTermination(routerSelf.parentTermination)
+	}
+	routees := routerSelf.routees
+	routerSelf.routees = nil
+	routerSelf.ring = nil
+	routerSelf.routeesM.Unlock()
+
+	for _, routee := range routees {
+		routee.Stop()
+	}
+}
+
+// IsClosed Check is Closed
+func (routerSelf *RouterDef[T]) IsClosed() bool {
+	return routerSelf.isClosed.Get()
+}
+
+func (routerSelf *RouterDef[T]) pick(message T) []*ActorDef[T] {
+	routerSelf.routeesM.Lock()
+	defer routerSelf.routeesM.Unlock()
+
+	routees := routerSelf.routees
+	if len(routees) == 0 {
+		return nil
+	}
+	switch routerSelf.option.Logic {
+	case RouterRandom:
+		return []*ActorDef[T]{routees[rand.Intn(len(routees))]}
+	case RouterBroadcast:
+		return DuplicateSlice(routees)
+	case RouterSmallestMailbox:
+		smallest := routees[0]
+		for _, routee := range routees[1:] {
+			if routee.GetMailboxSize() < smallest.GetMailboxSize() {
+				smallest = routee
+			}
+		}
+		return []*ActorDef[T]{smallest}
+	case RouterConsistentHash:
+		var key string
+		if routerSelf.option.HashKey != nil {
+			key = routerSelf.option.HashKey(message)
+		}
+		hash := routerHash(key)
+		ring := routerSelf.ring
+		i := sort.Search(len(ring), func(i int) bool {
+			return ring[i].hash >= hash
+		})
+		if i == len(ring) {
+			i = 0
+		}
+		return []*ActorDef[T]{routees[ring[i].index]}
+	default:
+		routee := routees[routerSelf.next%len(routees)]
+		routerSelf.next = (routerSelf.next + 1) % len(routees)
+		return []*ActorDef[T]{routee}
+	}
+}
+
+// spawnRoutee Spawn a routee which is replaced when it's terminated unexpectedly (routeesM should be locked)
+//
+// Errors of spawning by the parent(e.g. it's stopped) are final, the Router should be stopped.
+func (routerSelf *RouterDef[T]) spawnRoutee() (*ActorDef[T], error) {
+	var routee *ActorDef[T]
+	if routerSelf.parent != nil {
+		var err error
+		routee, err = routerSelf.parent.SpawnNamed("", routerSelf.effect, routerSelf.option.Routee)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		routee = ActorNewWithOptionGenerics(routerSelf.effect, routerSelf.option.Routee)
+	}
+
+	routee.SubscribeTermination(func(terminated ActorTerminated) {
+		// Not in the caller goroutine: it may hold routeesM
+		go routerSelf.replaceRoutee(routee)
+	})
+	return routee, nil
+}
+func (routerSelf *RouterDef[T]) replaceRoutee(routee *ActorDef[T]) {
+	if routerSelf.isClosed.Get() {
+		return
+	}
+
+	var err error
+	routerSelf.routeesM.Lock()
+	for i, current := range routerSelf.routees {
+		if current == routee {
+			// Keep the index, the consistent hash ring is not changed
+			var replacement *ActorDef[T]
+			replacement, err = routerSelf.spawnRoutee()
+			if err == nil {
+				routerSelf.routees[i] = replacement
+			}
+			break
+		}
+	}
+	routerSelf.routeesM.Unlock()
+
+	if err != nil {
+		routerSelf.Stop()
+	}
+}
+
+// rebuildRing Rebuild the consistent hash ring (routeesM should be locked)
+func (routerSelf *RouterDef[T]) rebuildRing() {
+	if routerSelf.option.Logic != RouterConsistentHash {
+		return
+	}
+
+	ring := make([]routerRingNode, 0, len(routerSelf.routees)*routerSelf.option.VirtualNodes)
+	for index := range routerSelf.routees {
+		for v := 0; v < routerSelf.option.VirtualNodes; v++ {
+			ring = append(ring, routerRingNode{
+				hash:  routerHash(strconv.Itoa(index) + "#" + strconv.Itoa(v)),
+				index: index,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		return ring[i].hash < ring[j].hash
+	})
+	routerSelf.ring = ring
+}
+
+func routerHash(key string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return hash.Sum32()
+}
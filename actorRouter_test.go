@@ -0,0 +1,135 @@
+package fpgo
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorRouter(t *testing.T) {
+	resultChannel := make(chan string, 20)
+	routeeEffect := func(self *ActorDef[string], input string) {
+		if input == "boom" {
+			panic(input)
+		}
+		resultChannel <- self.GetName() + ":" + input
+	}
+	receive := func(count int) map[string][]string {
+		result := map[string][]string{}
+		for i := 0; i < count; i++ {
+			val := <-resultChannel
+			for j := range val {
+				if val[j] == ':' {
+					result[val[:j]] = append(result[val[:j]], val[j+1:])
+					break
+				}
+			}
+		}
+		return result
+	}
+	actorRoot := ActorNewGenerics(func(self *ActorDef[string], input string) {})
+
+	// RoundRobin
+	router := RouterNewGenerics(actorRoot, routeeEffect, RouterOption[string]{Logic: RouterRoundRobin, Size: 3})
+	assert.Equal(t, 3, len(router.GetRoutees()))
+	assert.Equal(t, actorRoot, router.GetRoutees()[0].GetParent())
+	for i := 0; i < 6; i++ {
+		router.Send(strconv.Itoa(i))
+	}
+	result := receive(6)
+	assert.Equal(t, 3, len(result))
+	for _, received := range result {
+		assert.Equal(t, 2, len(received))
+	}
+	router.Stop()
+	assert.Equal(t, ErrActorClosed, router.TrySend("x"))
+
+	// Broadcast
+	router = RouterNewGenerics(nil, routeeEffect, RouterOption[string]{Logic: RouterBroadcast, Size: 3})
+	router.Send("all")
+	result = receive(3)
+	assert.Equal(t, 3, len(result))
+	router.Stop()
+
+	// Random & SmallestMailbox: every message should be routed to one of routees
+	for _, logic := range []RouterLogic{RouterRandom, RouterSmallestMailbox} {
+		router = RouterNewGenerics(nil, routeeEffect, RouterOption[string]{Logic: logic, Size: 2})
+		for i := 0; i < 4; i++ {
+			router.Send("x")
+		}
+		result = receive(4)
+		assert.Equal(t, 4, len(Flatten(Values(result)...)))
+		router.Stop()
+	}
+
+	// ConsistentHash: the same key goes to the same routee
+	router = RouterNewGenerics(nil, routeeEffect, RouterOption[string]{
+		Logic: RouterConsistentHash,
+		Size:  4,
+		HashKey: func(message string) string {
+			return message[:1]
+		},
+	})
+	for i := 0; i < 3; i++ {
+		router.Send("a" + strconv.Itoa(i))
+		router.Send("b" + strconv.Itoa(i))
+	}
+	result = receive(6)
+	routeeByKey := map[string]string{}
+	for routee, received := range result {
+		for _, val := range received {
+			if previous, ok := routeeByKey[val[:1]]; ok {
+				assert.Equal(t, previous, routee)
+			}
+			routeeByKey[val[:1]] = routee
+		}
+	}
+	assert.Equal(t, 2, len(routeeByKey))
+
+	// Resize
+	router.Resize(2)
+	assert.Equal(t, 2, len(router.GetRoutees()))
+	router.Resize(5)
+	assert.Equal(t, 5, len(router.GetRoutees()))
+	router.Stop()
+
+	// Failed routees are replaced
+	router = RouterNewGenerics(nil, routeeEffect, RouterOption[string]{Logic: RouterRoundRobin, Size: 1})
+	failed := router.GetRoutees()[0]
+	router.Send("boom")
+	<-failed.Done()
+	for i := 0; i < 100 && router.GetRoutees()[0] == failed; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.NotEqual(t, failed, router.GetRoutees()[0])
+	router.Send("alive")
+	result = receive(1)
+	assert.Equal(t, []string{"alive"}, result[router.GetRoutees()[0].GetName()])
+	router.Stop()
+
+	// Routers are stopped with their parents (no routees outside the parents)
+	parent := ActorNewGenerics(func(self *ActorDef[string], input string) {})
+	router = RouterNewGenerics(parent, routeeEffect, RouterOption[string]{Logic: RouterRoundRobin, Size: 3})
+	routees := router.GetRoutees()
+	parent.Stop()
+	<-parent.Done()
+	for i := 0; i < 100 && !router.IsClosed(); i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, router.IsClosed())
+	for _, routee := range router.GetRoutees() {
+		<-routee.Done()
+	}
+	for _, routee := range routees {
+		assert.True(t, routee.IsClosed())
+	}
+	for _, child := range parent.GetChildren() {
+		assert.True(t, child.IsClosed())
+	}
+	// Spawning by a stopped parent is final
+	router = RouterNewGenerics(parent, routeeEffect, RouterOption[string]{Logic: RouterRoundRobin, Size: 3})
+	assert.True(t, router.IsClosed())
+	assert.Equal(t, 0, len(router.GetRoutees()))
+}
@@ -0,0 +1,112 @@
+package fpgo
+
+import (
+	"sync"
+	"time"
+)
+
+// ScheduledDef Cancellable handle of scheduled messages
+type ScheduledDef struct {
+	isCancelled AtomBool
+	cancelOnce  sync.Once
+	cancelled   chan struct{}
+	done        chan struct{}
+}
+
+// ScheduleOnceGenerics Send the message to the target after the delay
+//
+// It's cancelled automatically when the target terminated(if the target has Done(), e.g. ActorDef).
+func ScheduleOnceGenerics[T any](delay time.Duration, target ActorHandle[T], message T) *ScheduledDef {
+	return schedule(delay, 0, target, message)
+}
+
+// ScheduleAtFixedRateGenerics Send the message to the target after the initialDelay and then every interval
+//
+// It's cancelled automatically when the target terminated(if the target has Done(), e.g. ActorDef).
+func ScheduleAtFixedRateGenerics[T any](initialDelay time.Duration, interval time.Duration, target ActorHandle[T], message T) *ScheduledDef {
+	if interval <= 0 {
+		return ScheduleOnceGenerics(initialDelay, target, message)
+	}
+
+	return schedule(initialDelay, interval, target, message)
+}
+
+func schedule[T any](initialDelay time.Duration, interval time.Duration, target ActorHandle[T], message T) *ScheduledDef {
+	scheduled := &ScheduledDef{
+		cancelled: make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	var targetDone <-chan struct{}
+	if withDone, ok := target.(interface{ Done() <-chan struct{} }); ok {
+		targetDone = withDone.Done()
+	}
+
+	go func() {
+		defer close(scheduled.done)
+
+		timer := time.NewTimer(initialDelay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			target.Send(message)
+		case <-scheduled.cancelled:
+			return
+		case <-targetDone:
+			return
+		}
+		if interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				target.Send(message)
+			case <-scheduled.cancelled:
+				return
+			case <-targetDone:
+				return
+			}
+		}
+	}()
+
+	return scheduled
+}
+
+// Cancel Cancel the scheduled messages not sent yet
+func (scheduledSelf *ScheduledDef) Cancel() {
+	scheduledSelf.cancelOnce.Do(func() {
+		scheduledSelf.isCancelled.Set(true)
+		close(scheduledSelf.cancelled)
+	})
+}
+
+// IsCancelled Check is it cancelled
+func (scheduledSelf *ScheduledDef) IsCancelled() bool {
+	return scheduledSelf.isCancelled.Get()
+}
+
+// Done Get the channel which will be closed after no more messages would be sent
+func (scheduledSelf *ScheduledDef) Done() <-chan struct{} {
+	return scheduledSelf.done
+}
+
+// ScheduleOnce Send the message to this Actor after the delay
+func (actorSelf *ActorDef[T]) ScheduleOnce(delay time.Duration, message T) *ScheduledDef {
+	return ScheduleOnceGenerics[T](delay, actorSelf, message)
+}
+
+// ScheduleAtFixedRate Send the message to this Actor after the initialDelay and then every interval
+func (actorSelf *ActorDef[T]) ScheduleAtFixedRate(initialDelay time.Duration, interval time.Duration, message T) *ScheduledDef {
+	return ScheduleAtFixedRateGenerics[T](initialDelay, interval, actorSelf, message)
+}
+
+// SetReceiveTimeout Send the message to this Actor whenever it has been idle for the timeout (0: disable)
+//
+// It should be called in the Actor goroutine (e.g. in the effect/PreStart).
+func (actorSelf *ActorDef[T]) SetReceiveTimeout(timeout time.Duration, message T) {
+	actorSelf.receiveTimeout = timeout
+	actorSelf.receiveTimeoutMessage = message
+}
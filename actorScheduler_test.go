@@ -0,0 +1,74 @@
+package fpgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorSchedule(t *testing.T) {
+	resultChannel := make(chan string, 10)
+
+	// Testee
+	actorRoot := ActorNewGenerics(func(self *ActorDef[string], input string) {
+		resultChannel <- input
+	})
+
+	// Once
+	scheduled := ScheduleOnceGenerics[string](5*time.Millisecond, actorRoot, "once")
+	assert.Equal(t, "once", <-resultChannel)
+	<-scheduled.Done()
+	assert.Equal(t, false, scheduled.IsCancelled())
+
+	// Cancel before sent
+	scheduled = actorRoot.ScheduleOnce(time.Hour, "never")
+	scheduled.Cancel()
+	<-scheduled.Done()
+	assert.Equal(t, true, scheduled.IsCancelled())
+
+	// Fixed rate
+	scheduled = actorRoot.ScheduleAtFixedRate(0, 5*time.Millisecond, "tick")
+	assert.Equal(t, "tick", <-resultChannel)
+	assert.Equal(t, "tick", <-resultChannel)
+	assert.Equal(t, "tick", <-resultChannel)
+	scheduled.Cancel()
+	<-scheduled.Done()
+
+	// Cancelled automatically after the target terminated
+	scheduled = actorRoot.ScheduleAtFixedRate(time.Hour, time.Hour, "never")
+	actorRoot.Stop()
+	<-scheduled.Done()
+}
+
+func TestActorReceiveTimeout(t *testing.T) {
+	resultChannel := make(chan string, 10)
+	cmdTimeout := "timeout"
+
+	// Testee
+	actorRoot := ActorNewWithOptionGenerics(func(self *ActorDef[string], input string) {
+		if input == cmdTimeout {
+			// Disable it after the first timeout
+			self.SetReceiveTimeout(0, "")
+		}
+		resultChannel <- input
+	}, &ActorOption[string]{
+		PreStart: func(self *ActorDef[string]) {
+			self.SetReceiveTimeout(20*time.Millisecond, cmdTimeout)
+		},
+	})
+
+	// Keep it busy
+	for i := 0; i < 3; i++ {
+		actorRoot.Send("ping")
+		assert.Equal(t, "ping", <-resultChannel)
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(t, 0, len(resultChannel))
+
+	// Idle
+	assert.Equal(t, cmdTimeout, <-resultChannel)
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, 0, len(resultChannel))
+	actorRoot.Stop()
+}
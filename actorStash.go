@@ -0,0 +1,33 @@
+package fpgo
+
+// Stash Defer the message(with the current sender), it'll be received again in order after UnstashAll()
+//
+// It should be called in the Actor goroutine (e.g. in the effect).
+func (actorSelf *ActorDef[T]) Stash(message T) error {
+	if actorSelf.stashCapacity > 0 && len(actorSelf.stash) >= actorSelf.stashCapacity {
+		return ErrActorStashFull
+	}
+
+	actorSelf.stash = append(actorSelf.stash, actorEnvelope[T]{message: message, sender: actorSelf.sender})
+	return nil
+}
+
+// UnstashAll Put all stashed messages back in order, they're received before the queued ones
+//
+// It should be called in the Actor goroutine (e.g. in the effect).
+func (actorSelf *ActorDef[T]) UnstashAll() {
+	if len(actorSelf.stash) == 0 {
+		return
+	}
+
+	stash := actorSelf.stash
+	actorSelf.stash = nil
+	actorSelf.mailbox.prepend(stash...)
+}
+
+// GetStashSize Get the number of stashed messages
+//
+// It should be called in the Actor goroutine (e.g. in the effect).
+func (actorSelf *ActorDef[T]) GetStashSize() int {
+	return len(actorSelf.stash)
+}
@@ -0,0 +1,44 @@
+package fpgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorStash(t *testing.T) {
+	var received []string
+	var stashErrors []error
+	resultChannel := make(chan []string, 1)
+	cmdReady := "ready"
+	cmdGet := "get"
+
+	// Testee
+	actorRoot := ActorNewWithOptionGenerics(func(self *ActorDef[string], input string) {
+		if input == cmdReady {
+			self.Become(func(self *ActorDef[string], input string) {
+				if input == cmdGet {
+					resultChannel <- received
+					return
+				}
+				received = append(received, input)
+			}, false)
+			self.UnstashAll()
+			return
+		}
+
+		// Not initialized yet
+		stashErrors = append(stashErrors, self.Stash(input))
+	}, &ActorOption[string]{StashCapacity: 3})
+
+	actorRoot.Send("1")
+	actorRoot.Send("2")
+	actorRoot.Send("3")
+	actorRoot.Send("4")
+	actorRoot.Send(cmdReady)
+	actorRoot.Send("5")
+	actorRoot.Send(cmdGet)
+
+	assert.Equal(t, []string{"1", "2", "3", "5"}, <-resultChannel)
+	assert.Equal(t, []error{nil, nil, nil, ErrActorStashFull}, stashErrors)
+}
@@ -0,0 +1,206 @@
+package fpgo
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// SupervisorDirective What to do with a failed(panicked) Actor
+type SupervisorDirective int
+
+const (
+	// SupervisorResume Keep the state(context) and continue with the next message
+	SupervisorResume SupervisorDirective = iota
+	// SupervisorRestart Reset the state(context) and continue with the next message
+	SupervisorRestart
+	// SupervisorStop Stop the failed Actor
+	SupervisorStop
+	// SupervisorEscalate Fail the parent Actor by the same cause, the decision for the parent is applied to this Actor too
+	SupervisorEscalate
+)
+
+// SupervisorStrategyKind Which Actors are affected by a directive
+type SupervisorStrategyKind int
+
+const (
+	// SupervisorOneForOne Apply the directive to the failed Actor only
+	SupervisorOneForOne SupervisorStrategyKind = iota
+	// SupervisorAllForOne Apply the directive to the failed Actor and all of its siblings
+	SupervisorAllForOne
+)
+
+// SupervisorStrategyDef SupervisorStrategy inspired by Akka
+type SupervisorStrategyDef struct {
+	Kind SupervisorStrategyKind
+	// MaxRestarts Max restarts within WithinDuration, the Actor will be stopped when exceeded (negative: unlimited)
+	MaxRestarts int
+	// WithinDuration The window of MaxRestarts (0: no window, counting since the Actor created)
+	WithinDuration time.Duration
+	// Decider Decide the directive by the panic cause (nil: always restart)
+	Decider func(cause interface{}) SupervisorDirective
+}
+
+// OneForOne New a OneForOne SupervisorStrategy
+func (strategySelf *SupervisorStrategyDef) OneForOne(maxRestarts int, within time.Duration, decider func(cause interface{}) SupervisorDirective) *SupervisorStrategyDef {
+	return &SupervisorStrategyDef{
+		Kind:           SupervisorOneForOne,
+		MaxRestarts:    maxRestarts,
+		WithinDuration: within,
+		Decider:        decider,
+	}
+}
+
+// AllForOne New a AllForOne SupervisorStrategy
+func (strategySelf *SupervisorStrategyDef) AllForOne(maxRestarts int, within time.Duration, decider func(cause interface{}) SupervisorDirective) *SupervisorStrategyDef {
+	return &SupervisorStrategyDef{
+		Kind:           SupervisorAllForOne,
+		MaxRestarts:    maxRestarts,
+		WithinDuration: within,
+		Decider:        decider,
+	}
+}
+
+// Decide Decide the directive by the panic cause
+func (strategySelf *SupervisorStrategyDef) Decide(cause interface{}) SupervisorDirective {
+	if strategySelf.Decider == nil {
+		return SupervisorRestart
+	}
+
+	return strategySelf.Decider(cause)
+}
+
+// handleFailure Handle the failure(panic) of the effect, in the Actor goroutine
+func (actorSelf *ActorDef[T]) handleFailure(cause interface{}) {
+	actorSelf.handleFailureThen(cause, nil)
+}
+
+// handleFailureThen Handle the failure, then(optional) is called with the directive applied to the Actor
+func (actorSelf *ActorDef[T]) handleFailureThen(cause interface{}, then func(SupervisorDirective)) {
+	strategy := actorSelf.supervisor
+	if strategy == nil {
+		actorSelf.stopByFailure(cause)
+		if then != nil {
+			then(SupervisorStop)
+		}
+		return
+	}
+
+	directive := strategy.Decide(cause)
+	if directive == SupervisorRestart && (!actorSelf.allowRestart(strategy)) {
+		directive = SupervisorStop
+	}
+
+	if then != nil {
+		defer func() {
+			if directive != SupervisorEscalate {
+				then(directive)
+			}
+		}()
+	}
+	switch directive {
+	case SupervisorResume:
+		return
+	case SupervisorEscalate:
+		parent := actorSelf.parent
+		if parent == nil {
+			directive = SupervisorStop
+			actorSelf.stopByFailure(cause)
+			return
+		}
+		parent.postSystem(func() {
+			parent.handleFailureThen(cause, func(parentDirective SupervisorDirective) {
+				// Apply the decision for the parent to this Actor too
+				actorSelf.postSystem(func() {
+					actorSelf.applyEscalated(parentDirective, cause)
+				})
+				if then != nil {
+					then(parentDirective)
+				}
+			})
+		})
+		return
+	}
+
+	targets := []*ActorDef[T]{actorSelf}
+	if strategy.Kind == SupervisorAllForOne && actorSelf.parent != nil {
+		targets = actorSelf.parent.GetChildren()
+	}
+	for _, target := range targets {
+		target := target
+		if target == actorSelf {
+			if directive == SupervisorStop {
+				target.stopByFailure(cause)
+				continue
+			}
+			target.applyDirective(directive)
+			continue
+		}
+		target.postSystem(func() {
+			target.applyDirective(directive)
+		})
+	}
+}
+func (actorSelf *ActorDef[T]) applyDirective(directive SupervisorDirective) {
+	switch directive {
+	case SupervisorRestart:
+		actorSelf.restart()
+	case SupervisorStop:
+		actorSelf.Close()
+	}
+}
+
+// restart Restart the Actor by the lifecycle: PostStop, resetting(OnRestart) & PreStart
+func (actorSelf *ActorDef[T]) restart() {
+	atomic.AddUint64(&actorSelf.metrics.restarts, 1)
+	actorSelf.callPostStop()
+
+	actorSelf.context = DuplicateMap(actorSelf.initContext)
+	actorSelf.behaviors = nil
+	// Stashed messages won't be received anymore
+	stash := actorSelf.stash
+	actorSelf.stash = nil
+	for _, envelope := range stash {
+		actorSelf.publishDeadLetter(envelope, ErrActorRestarted)
+	}
+
+	// Failures during restarts stop the Actor (instead of restarting it recursively)
+	cause := actorSelf.callHook(actorSelf.onRestart)
+	if cause == nil {
+		cause = actorSelf.callHook(actorSelf.preStart)
+	}
+	if cause != nil {
+		actorSelf.stopByFailure(cause)
+	}
+}
+func (actorSelf *ActorDef[T]) applyEscalated(directive SupervisorDirective, cause interface{}) {
+	if directive == SupervisorStop {
+		actorSelf.stopByFailure(cause)
+		return
+	}
+	actorSelf.applyDirective(directive)
+}
+func (actorSelf *ActorDef[T]) stopByFailure(cause interface{}) {
+	actorSelf.failureCause = cause
+	actorSelf.Stop()
+}
+func (actorSelf *ActorDef[T]) allowRestart(strategy *SupervisorStrategyDef) bool {
+	if strategy.MaxRestarts < 0 {
+		return true
+	}
+
+	now := time.Now()
+	if strategy.WithinDuration > 0 {
+		actorSelf.restarts = Filter(func(restartedAt time.Time, _ int) bool {
+			return now.Sub(restartedAt) < strategy.WithinDuration
+		}, actorSelf.restarts...)
+	}
+	if len(actorSelf.restarts) >= strategy.MaxRestarts {
+		return false
+	}
+	actorSelf.restarts = append(actorSelf.restarts, now)
+
+	return true
+}
+
+// SupervisorStrategy SupervisorStrategy utils instance
+var SupervisorStrategy SupervisorStrategyDef
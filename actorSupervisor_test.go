@@ -0,0 +1,271 @@
+package fpgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorSupervisorOneForOne(t *testing.T) {
+	var resultChannel chan interface{}
+	cmdBoom := "boom"
+	cmdGet := "get"
+
+	counterEffect := func(self *ActorDef[interface{}], input interface{}) {
+		if input == cmdBoom {
+			panic(cmdBoom)
+		}
+		if input == cmdGet {
+			resultChannel <- self.context["count"]
+			return
+		}
+
+		count, _ := Maybe.Just(self.context["count"]).ToInt()
+		self.context["count"] = count + 1
+	}
+
+	// Restart: the state should be reset
+	resultChannel = make(chan interface{}, 1)
+	actorRoot := Actor.New(func(self *ActorDef[interface{}], input interface{}) {})
+	child := actorRoot.SpawnWithOption(counterEffect, &ActorOption[interface{}]{
+		Supervisor: SupervisorStrategy.OneForOne(-1, 0, nil),
+	})
+	child.Send(1)
+	child.Send(1)
+	child.Send(cmdGet)
+	assert.Equal(t, 2, <-resultChannel)
+	child.Send(cmdBoom)
+	child.Send(1)
+	child.Send(cmdGet)
+	assert.Equal(t, 1, <-resultChannel)
+	assert.Equal(t, false, child.IsClosed())
+
+	// Resume: the state should be kept
+	child = actorRoot.SpawnWithOption(counterEffect, &ActorOption[interface{}]{
+		Supervisor: SupervisorStrategy.OneForOne(-1, 0, func(cause interface{}) SupervisorDirective {
+			return SupervisorResume
+		}),
+	})
+	child.Send(1)
+	child.Send(cmdBoom)
+	child.Send(1)
+	child.Send(cmdGet)
+	assert.Equal(t, 2, <-resultChannel)
+
+	// MaxRestarts exceeded: the Actor should be stopped
+	child = actorRoot.SpawnWithOption(counterEffect, &ActorOption[interface{}]{
+		Supervisor: SupervisorStrategy.OneForOne(1, time.Minute, nil),
+	})
+	child.Send(cmdBoom)
+	child.Send(cmdGet)
+	assert.Equal(t, nil, <-resultChannel)
+	assert.Equal(t, false, child.IsClosed())
+	child.Send(cmdBoom)
+	<-child.Done()
+	assert.Equal(t, true, child.IsClosed())
+
+	// No Supervisor: the Actor should be stopped
+	child = actorRoot.Spawn(counterEffect)
+	child.Send(cmdBoom)
+	<-child.Done()
+	assert.Equal(t, true, child.IsClosed())
+}
+
+func TestActorSupervisorAllForOne(t *testing.T) {
+	resultChannel := make(chan interface{}, 2)
+	cmdBoom := "boom"
+	cmdGet := "get"
+
+	counterEffect := func(self *ActorDef[interface{}], input interface{}) {
+		if input == cmdBoom {
+			panic(cmdBoom)
+		}
+		if input == cmdGet {
+			resultChannel <- self.context["count"]
+			return
+		}
+
+		count, _ := Maybe.Just(self.context["count"]).ToInt()
+		self.context["count"] = count + 1
+	}
+	option := &ActorOption[interface{}]{
+		Supervisor: SupervisorStrategy.AllForOne(-1, 0, nil),
+	}
+
+	actorRoot := Actor.New(func(self *ActorDef[interface{}], input interface{}) {})
+	child1 := actorRoot.SpawnWithOption(counterEffect, option)
+	child2 := actorRoot.SpawnWithOption(counterEffect, option)
+	child1.Send(1)
+	child2.Send(1)
+	child2.Send(1)
+	child2.Send(cmdGet)
+	assert.Equal(t, 2, <-resultChannel)
+	child1.Send(cmdBoom)
+
+	// Both of them should be restarted
+	child1.Send(cmdGet)
+	assert.Equal(t, nil, <-resultChannel)
+	child2.Send(cmdGet)
+	assert.Equal(t, nil, <-resultChannel)
+}
+
+func TestActorSupervisorEscalate(t *testing.T) {
+	resultChannel := make(chan interface{}, 1)
+	cmdBoom := "boom"
+	cmdGet := "get"
+	cmdSpawn := "spawn"
+
+	var child *ActorDef[interface{}]
+	started := make(chan string, 2)
+	actorRoot := Actor.NewWithOption(func(self *ActorDef[interface{}], input interface{}) {
+		if input == cmdSpawn {
+			child = self.SpawnWithOption(func(self *ActorDef[interface{}], input interface{}) {
+				if input == cmdBoom {
+					panic(input)
+				}
+				if input == cmdGet {
+					resultChannel <- self.context["count"]
+					return
+				}
+
+				count, _ := Maybe.Just(self.context["count"]).ToInt()
+				self.context["count"] = count + 1
+			}, &ActorOption[interface{}]{
+				Supervisor: SupervisorStrategy.OneForOne(-1, 0, func(cause interface{}) SupervisorDirective {
+					return SupervisorEscalate
+				}),
+				OnRestart: func(self *ActorDef[interface{}]) {
+					started <- "child"
+				},
+			})
+			return
+		}
+		if input == cmdGet {
+			resultChannel <- self.context["count"]
+			return
+		}
+
+		count, _ := Maybe.Just(self.context["count"]).ToInt()
+		self.context["count"] = count + 1
+	}, &ActorOption[interface{}]{
+		Supervisor: SupervisorStrategy.OneForOne(-1, 0, nil),
+		OnRestart: func(self *ActorDef[interface{}]) {
+			started <- "parent"
+		},
+	})
+	actorRoot.Send(cmdSpawn)
+	actorRoot.Send(1)
+	actorRoot.Send(cmdGet)
+	assert.Equal(t, 1, <-resultChannel)
+	child.Send(1)
+	child.Send(cmdGet)
+	assert.Equal(t, 1, <-resultChannel)
+
+	// The parent should be restarted, and so should the child
+	child.Send(cmdBoom)
+	assert.Equal(t, "parent", <-started)
+	assert.Equal(t, "child", <-started)
+	actorRoot.Send(cmdGet)
+	assert.Equal(t, nil, <-resultChannel)
+	child.Send(cmdGet)
+	assert.Equal(t, nil, <-resultChannel)
+	assert.Equal(t, false, child.IsClosed())
+
+	// The parent should be stopped, and so should the child
+	actorRoot.supervisor = SupervisorStrategy.OneForOne(-1, 0, func(cause interface{}) SupervisorDirective {
+		return SupervisorStop
+	})
+	child.Send(cmdBoom)
+	<-child.Done()
+	<-actorRoot.Done()
+	assert.Equal(t, cmdBoom, child.failureCause)
+}
+
+func TestActorSupervisorRestartLifecycle(t *testing.T) {
+	system := ActorSystem.New("restart")
+	defer system.Shutdown()
+	events := make(chan string, 10)
+	deadLetters := make(chan ActorDeadLetter, 10)
+	system.GetDeadLetters().Subscribe(func(deadLetter ActorDeadLetter) {
+		deadLetters <- deadLetter
+	})
+
+	// Restart: PostStop, OnRestart & PreStart, and the stash is discarded
+	actor, _ := ActorOfGenerics(system, "restarted", func(self *ActorDef[string], input string) {
+		switch input {
+		case "boom":
+			panic(input)
+		case "stash":
+			self.Stash(input)
+		default:
+			events <- input
+		}
+	}, &ActorOption[string]{
+		Supervisor: SupervisorStrategy.OneForOne(-1, 0, nil),
+		PreStart: func(self *ActorDef[string]) {
+			events <- "PreStart"
+		},
+		PostStop: func(self *ActorDef[string]) {
+			events <- "PostStop"
+		},
+		OnRestart: func(self *ActorDef[string]) {
+			events <- "OnRestart"
+		},
+	})
+	assert.Equal(t, "PreStart", <-events)
+	actor.Send("stash")
+	actor.Send("boom")
+	actor.Send("alive")
+	assert.Equal(t, "PostStop", <-events)
+	assert.Equal(t, "OnRestart", <-events)
+	assert.Equal(t, "PreStart", <-events)
+	assert.Equal(t, "alive", <-events)
+	deadLetter := <-deadLetters
+	assert.Equal(t, "stash", deadLetter.Message)
+	assert.Equal(t, ErrActorRestarted, deadLetter.Reason)
+	assert.Equal(t, 0, actor.GetStashSize())
+
+	// Failures during restarts stop the Actor
+	actor, _ = ActorOfGenerics(system, "failed", func(self *ActorDef[string], input string) {
+		panic(input)
+	}, &ActorOption[string]{
+		Supervisor: SupervisorStrategy.OneForOne(-1, 0, nil),
+		OnRestart: func(self *ActorDef[string]) {
+			panic("OnRestart")
+		},
+	})
+	var terminated ActorTerminated
+	actor.Send("boom")
+	<-actor.Done()
+	actor.SubscribeTermination(func(event ActorTerminated) {
+		terminated = event
+	})
+	assert.Equal(t, "OnRestart", terminated.Cause)
+
+	// FSM: restarted in the initial state with its data
+	fsm := FSMNewGenerics[string](fsmTestDisconnected, fsmTestData{}).
+		When(fsmTestDisconnected, 0, func(fsm *FSMDef[string, fsmTestState, fsmTestData], event FSMEvent[string, fsmTestData]) *FSMNextState[fsmTestState, fsmTestData] {
+			return fsm.Goto(fsmTestConnected).Using(fsmTestData{attempts: 1})
+		}).
+		When(fsmTestConnected, 0, func(fsm *FSMDef[string, fsmTestState, fsmTestData], event FSMEvent[string, fsmTestData]) *FSMNextState[fsmTestState, fsmTestData] {
+			if event.Message == "boom" {
+				panic(event.Message)
+			}
+			return fsm.Stay()
+		}).
+		Start(&ActorOption[string]{
+			Supervisor: SupervisorStrategy.OneForOne(-1, 0, nil),
+			PreStart: func(self *ActorDef[string]) {
+				events <- "FSM PreStart"
+			},
+		})
+	assert.Equal(t, "FSM PreStart", <-events)
+	fsm.Send("connect")
+	fsm.Send("boom")
+	assert.Equal(t, "FSM PreStart", <-events)
+	assert.Equal(t, fsmTestDisconnected, fsm.GetState())
+	assert.Equal(t, fsmTestData{}, fsm.GetData())
+	fsm.Stop()
+	<-fsm.Done()
+}
@@ -0,0 +1,223 @@
+package fpgo
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// ActorPathUser The parent path of top-level Actors
+	ActorPathUser = "/user"
+)
+
+var (
+	ErrActorInvalidName      = fmt.Errorf("ErrActorInvalidName")
+	ErrActorNameConflict     = fmt.Errorf("ErrActorNameConflict")
+	ErrActorSystemTerminated = fmt.Errorf("ErrActorSystemTerminated")
+)
+
+// ActorRef Type-erased Actor reference (for lookups of ActorSystem)
+type ActorRef interface {
+	GetID() uint64
+	GetName() string
+	GetPath() string
+	SendForInterface(message interface{}) error
+	TellForInterface(message interface{}, sender ActorRef) error
+	IsClosed() bool
+	Close()
+
+	SubscribeTermination(fn func(ActorTerminated)) *Subscription[ActorTerminated]
+	UnsubscribeTermination(s *Subscription[ActorTerminated])
+}
+
+// ActorSystemDef ActorSystem inspired by Akka, the root of named Actors
+type ActorSystemDef struct {
+	name         string
+	isTerminated bool
+
+	registry   map[string]ActorRef
+	registryM  sync.RWMutex
+	terminated chan struct{}
+
+	deadLetters *DeadLettersDef
+	eventStream *EventStreamDef
+}
+
+// New New ActorSystem instance
+func (systemSelf *ActorSystemDef) New(name string) *ActorSystemDef {
+	return &ActorSystemDef{
+		name:        name,
+		registry:    map[string]ActorRef{},
+		terminated:  make(chan struct{}),
+		deadLetters: DeadLetters.New(),
+		eventStream: EventStream.New(),
+	}
+}
+
+// ActorOfGenerics New a top-level Actor(/user/name) of the ActorSystem
+//
+// The name is generated if it's empty.
+func ActorOfGenerics[T any](system *ActorSystemDef, name string, effect func(*ActorDef[T], T), option *ActorOption[T]) (*ActorDef[T], error) {
+	if strings.Contains(name, "/") {
+		return nil, ErrActorInvalidName
+	}
+
+	newOne := actorNew(effect, nil, map[string]interface{}{}, option)
+	newOne.system = system
+	newOne.setName(ActorPathUser, name)
+	err := system.register(newOne)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOne.start(), nil
+}
+
+// GetName Get the name of the ActorSystem
+func (systemSelf *ActorSystemDef) GetName() string {
+	return systemSelf.name
+}
+
+// GetDeadLetters Get the DeadLetters of the ActorSystem
+func (systemSelf *ActorSystemDef) GetDeadLetters() *DeadLettersDef {
+	if systemSelf.deadLetters == nil {
+		return defaultDeadLetters
+	}
+
+	return systemSelf.deadLetters
+}
+
+// GetEventStream Get the EventStream of the ActorSystem
+func (systemSelf *ActorSystemDef) GetEventStream() *EventStreamDef {
+	if systemSelf.eventStream == nil {
+		return defaultEventStream
+	}
+
+	return systemSelf.eventStream
+}
+
+// Lookup Get the Actor by its path
+func (systemSelf *ActorSystemDef) Lookup(path string) (ActorRef, bool) {
+	systemSelf.registryM.RLock()
+	defer systemSelf.registryM.RUnlock()
+	actor, ok := systemSelf.registry[path]
+	return actor, ok
+}
+
+// ActorLookupGenerics Get the Actor by its path (nil if not found or T doesn't match)
+func ActorLookupGenerics[T any](system *ActorSystemDef, path string) *ActorDef[T] {
+	actor, ok := system.Lookup(path)
+	if !ok {
+		return nil
+	}
+	typed, _ := actor.(*ActorDef[T])
+	return typed
+}
+
+// ActorSelection Select Actors by the path pattern (path.Match syntax, e.g. /user/orders/*)
+func (systemSelf *ActorSystemDef) ActorSelection(pattern string) *ActorSelectionDef {
+	return &ActorSelectionDef{system: systemSelf, pattern: pattern}
+}
+
+// Shutdown Stop all Actors of the ActorSystem & wait for their termination, no more Actors could be created
+//
+// It shouldn't be called in the goroutines of its Actors (they would wait for themselves),
+// Close() the Actors there & use AwaitTermination() elsewhere instead.
+func (systemSelf *ActorSystemDef) Shutdown() {
+	systemSelf.registryM.Lock()
+	if systemSelf.isTerminated {
+		systemSelf.registryM.Unlock()
+		<-systemSelf.terminated
+		return
+	}
+	systemSelf.isTerminated = true
+	actors := Values(systemSelf.registry)
+	systemSelf.registryM.Unlock()
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(len(actors))
+	for _, actor := range actors {
+		actor.SubscribeTermination(func(ActorTerminated) {
+			waitGroup.Done()
+		})
+		actor.Close()
+	}
+	waitGroup.Wait()
+	close(systemSelf.terminated)
+}
+
+// AwaitTermination Wait for the termination of the ActorSystem(all Actors terminated after Shutdown) until ctx is done
+func (systemSelf *ActorSystemDef) AwaitTermination(ctx context.Context) error {
+	select {
+	case <-systemSelf.terminated:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsTerminated Check is the ActorSystem shut down
+func (systemSelf *ActorSystemDef) IsTerminated() bool {
+	systemSelf.registryM.RLock()
+	defer systemSelf.registryM.RUnlock()
+	return systemSelf.isTerminated
+}
+
+func (systemSelf *ActorSystemDef) register(actor ActorRef) error {
+	systemSelf.registryM.Lock()
+	defer systemSelf.registryM.Unlock()
+	if systemSelf.isTerminated {
+		return ErrActorSystemTerminated
+	}
+	if existing, ok := systemSelf.registry[actor.GetPath()]; ok && !existing.IsClosed() {
+		return ErrActorNameConflict
+	}
+
+	systemSelf.registry[actor.GetPath()] = actor
+	return nil
+}
+func (systemSelf *ActorSystemDef) unregister(actor ActorRef) {
+	systemSelf.registryM.Lock()
+	defer systemSelf.registryM.Unlock()
+	if systemSelf.registry[actor.GetPath()] == actor {
+		delete(systemSelf.registry, actor.GetPath())
+	}
+}
+
+// ActorSelectionDef ActorSelection inspired by Akka, Actors selected by a path pattern
+type ActorSelectionDef struct {
+	system  *ActorSystemDef
+	pattern string
+}
+
+// Resolve Get the matching Actors(sorted by paths)
+func (selectionSelf *ActorSelectionDef) Resolve() []ActorRef {
+	system := selectionSelf.system
+	system.registryM.RLock()
+	var result []ActorRef
+	for actorPath, actor := range system.registry {
+		if matched, _ := path.Match(selectionSelf.pattern, actorPath); matched {
+			result = append(result, actor)
+		}
+	}
+	system.registryM.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetPath() < result[j].GetPath()
+	})
+	return result
+}
+
+// Send Send the message to all matching Actors (the ones whose T doesn't match are skipped)
+func (selectionSelf *ActorSelectionDef) Send(message interface{}) {
+	for _, actor := range selectionSelf.Resolve() {
+		actor.SendForInterface(message)
+	}
+}
+
+// ActorSystem ActorSystem utils instance
+var ActorSystem ActorSystemDef
@@ -0,0 +1,100 @@
+package fpgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorSystem(t *testing.T) {
+	var err error
+	resultChannel := make(chan string, 3)
+	system := ActorSystem.New("test")
+
+	orders, err := ActorOfGenerics(system, "orders", func(self *ActorDef[string], input string) {}, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "/user/orders", orders.GetPath())
+	_, err = ActorOfGenerics(system, "orders", func(self *ActorDef[string], input string) {}, nil)
+	assert.Equal(t, ErrActorNameConflict, err)
+	_, err = ActorOfGenerics(system, "a/b", func(self *ActorDef[string], input string) {}, nil)
+	assert.Equal(t, ErrActorInvalidName, err)
+
+	// Hierarchical names
+	workerEffect := func(self *ActorDef[string], input string) {
+		resultChannel <- self.GetName() + ":" + input
+	}
+	worker1, err := orders.SpawnNamed("worker-1", workerEffect, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "/user/orders/worker-1", worker1.GetPath())
+	_, err = orders.SpawnNamed("worker-1", workerEffect, nil)
+	assert.Equal(t, ErrActorNameConflict, err)
+	worker2, _ := orders.SpawnNamed("worker-2", workerEffect, nil)
+	assert.Equal(t, worker2, orders.GetChildByName("worker-2"))
+	// Generated names should be unique
+	anonymous1 := orders.Spawn(workerEffect)
+	anonymous2 := orders.Spawn(workerEffect)
+	assert.NotEqual(t, anonymous1.GetID(), anonymous2.GetID())
+	assert.NotEqual(t, anonymous1.GetPath(), anonymous2.GetPath())
+
+	// Lookup
+	assert.Equal(t, worker1, ActorLookupGenerics[string](system, "/user/orders/worker-1"))
+	assert.Nil(t, ActorLookupGenerics[int](system, "/user/orders/worker-1"))
+	assert.Nil(t, ActorLookupGenerics[string](system, "/user/orders/worker-3"))
+
+	// ActorSelection
+	selection := system.ActorSelection("/user/orders/worker-*")
+	assert.Equal(t, []ActorRef{worker1, worker2}, selection.Resolve())
+	selection.Send("hello")
+	selection.Send(1)
+	actual := []string{<-resultChannel, <-resultChannel}
+	assert.ElementsMatch(t, []string{"worker-1:hello", "worker-2:hello"}, actual)
+
+	// The name could be reused after stopped
+	worker1.Close()
+	_, ok := system.Lookup("/user/orders/worker-1")
+	assert.Equal(t, false, ok)
+	_, err = orders.SpawnNamed("worker-1", workerEffect, nil)
+	assert.Equal(t, nil, err)
+
+	// Not terminated before Shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	assert.Equal(t, context.DeadlineExceeded, system.AwaitTermination(ctx))
+	cancel()
+
+	// Shutdown (waits for the termination)
+	system.Shutdown()
+	assert.Equal(t, true, system.IsTerminated())
+	assert.Equal(t, true, orders.IsClosed())
+	assert.Equal(t, true, worker2.IsClosed())
+	select {
+	case <-orders.Done():
+	default:
+		assert.Fail(t, "orders should have terminated")
+	}
+	select {
+	case <-worker2.Done():
+	default:
+		assert.Fail(t, "worker-2 should have terminated")
+	}
+	assert.Equal(t, nil, system.AwaitTermination(context.Background()))
+	// Shutdown again
+	system.Shutdown()
+	assert.Equal(t, 0, len(system.ActorSelection("/user/*").Resolve()))
+	_, err = ActorOfGenerics(system, "orders", func(self *ActorDef[string], input string) {}, nil)
+	assert.Equal(t, ErrActorSystemTerminated, err)
+
+	// No stray Actors spawned by a stopped parent
+	stray := orders.Spawn(workerEffect)
+	assert.Equal(t, true, stray.IsClosed())
+	assert.Equal(t, orders.GetSystem(), stray.GetSystem())
+	assert.Equal(t, ErrActorClosed, stray.TrySend("hello"))
+	<-stray.Done()
+	var cause interface{}
+	stray.SubscribeTermination(func(terminated ActorTerminated) {
+		cause = terminated.Cause
+	})
+	assert.Equal(t, ErrActorClosed, cause)
+	assert.Equal(t, 0, len(system.ActorSelection("/user/*").Resolve()))
+}
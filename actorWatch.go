@@ -0,0 +1,123 @@
+package fpgo
+
+import (
+	"sync"
+)
+
+// ActorTerminated Message delivered to the watchers when the watched Actor terminated
+type ActorTerminated struct {
+	Actor ActorRef
+	// Cause The panic cause if the Actor was stopped by a failure of its effect (nil: stopped normally)
+	Cause interface{}
+}
+
+type actorWatchState struct {
+	isTerminated bool
+	publisher    *PublisherDef[ActorTerminated]
+	watching     map[uint64]actorWatching
+	watchM       sync.Mutex
+}
+
+type actorWatching struct {
+	target       ActorRef
+	subscription *Subscription[ActorTerminated]
+}
+
+// SubscribeTermination Subscribe the termination of the Actor
+//
+// fn is called immediately if the Actor has terminated already (and nil is returned).
+func (actorSelf *ActorDef[T]) SubscribeTermination(fn func(ActorTerminated)) *Subscription[ActorTerminated] {
+	actorSelf.watchState.watchM.Lock()
+	if actorSelf.watchState.isTerminated {
+		actorSelf.watchState.watchM.Unlock()
+		fn(ActorTerminated{Actor: actorSelf, Cause: actorSelf.failureCause})
+		return nil
+	}
+	if actorSelf.watchState.publisher == nil {
+		actorSelf.watchState.publisher = PublisherNewGenerics[ActorTerminated]()
+	}
+	// Subscribe with watchM locked: publishTerminated can't publish before it
+	defer actorSelf.watchState.watchM.Unlock()
+	return actorSelf.watchState.publisher.Subscribe(Subscription[ActorTerminated]{OnNext: fn})
+}
+
+// UnsubscribeTermination Unsubscribe the termination of the Actor by the Subscription
+func (actorSelf *ActorDef[T]) UnsubscribeTermination(s *Subscription[ActorTerminated]) {
+	actorSelf.watchState.watchM.Lock()
+	publisher := actorSelf.watchState.publisher
+	actorSelf.watchState.watchM.Unlock()
+
+	if publisher != nil && s != nil {
+		publisher.Unsubscribe(s)
+	}
+}
+
+// Watch Watch the termination of the target, an ActorTerminated will be sent to this Actor
+//
+// T should be able to hold ActorTerminated (e.g. interface{}), otherwise use WatchWith.
+func (actorSelf *ActorDef[T]) Watch(target ActorRef) {
+	actorSelf.WatchWith(target, nil)
+}
+
+// WatchWith Watch the termination of the target, the ActorTerminated will be wrapped as T and sent to this Actor
+func (actorSelf *ActorDef[T]) WatchWith(target ActorRef, wrap func(ActorTerminated) T) {
+	actorSelf.Unwatch(target)
+
+	state := &actorSelf.watchState
+	state.watchM.Lock()
+	if state.watching == nil {
+		state.watching = map[uint64]actorWatching{}
+	}
+	state.watching[target.GetID()] = actorWatching{target: target}
+	state.watchM.Unlock()
+
+	subscription := target.SubscribeTermination(func(terminated ActorTerminated) {
+		state.watchM.Lock()
+		delete(state.watching, target.GetID())
+		state.watchM.Unlock()
+
+		if wrap != nil {
+			actorSelf.TrySend(wrap(terminated))
+			return
+		}
+		actorSelf.SendForInterface(terminated)
+	})
+
+	state.watchM.Lock()
+	if watching, ok := state.watching[target.GetID()]; ok {
+		watching.subscription = subscription
+		state.watching[target.GetID()] = watching
+	}
+	state.watchM.Unlock()
+}
+
+// Unwatch Stop watching the termination of the target
+func (actorSelf *ActorDef[T]) Unwatch(target ActorRef) {
+	state := &actorSelf.watchState
+	state.watchM.Lock()
+	watching, ok := state.watching[target.GetID()]
+	delete(state.watching, target.GetID())
+	state.watchM.Unlock()
+
+	if ok {
+		target.UnsubscribeTermination(watching.subscription)
+	}
+}
+
+// publishTerminated Notify the watchers & stop watching others, in the Actor goroutine
+func (actorSelf *ActorDef[T]) publishTerminated() {
+	state := &actorSelf.watchState
+	state.watchM.Lock()
+	state.isTerminated = true
+	publisher := state.publisher
+	watchingList := Values(state.watching)
+	state.watching = nil
+	state.watchM.Unlock()
+
+	for _, watching := range watchingList {
+		watching.target.UnsubscribeTermination(watching.subscription)
+	}
+	if publisher != nil {
+		publisher.Publish(ActorTerminated{Actor: actorSelf, Cause: actorSelf.failureCause})
+	}
+}
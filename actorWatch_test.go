@@ -0,0 +1,81 @@
+package fpgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorWatch(t *testing.T) {
+	resultChannel := make(chan ActorTerminated, 2)
+	cmdBoom := "boom"
+
+	// Testee
+	watcher := Actor.New(func(self *ActorDef[interface{}], input interface{}) {
+		if terminated, ok := input.(ActorTerminated); ok {
+			resultChannel <- terminated
+		}
+	})
+	child := watcher.Spawn(func(self *ActorDef[interface{}], input interface{}) {
+		if input == cmdBoom {
+			panic(cmdBoom)
+		}
+	})
+
+	// Stopped by failure
+	watcher.Watch(child)
+	child.Send(cmdBoom)
+	terminated := <-resultChannel
+	assert.Equal(t, child, terminated.Actor)
+	assert.Equal(t, cmdBoom, terminated.Cause)
+
+	// Stopped normally
+	other := Actor.New(func(self *ActorDef[interface{}], input interface{}) {})
+	watcher.Watch(other)
+	other.Stop()
+	terminated = <-resultChannel
+	assert.Equal(t, other, terminated.Actor)
+	assert.Equal(t, nil, terminated.Cause)
+
+	// Watching a terminated Actor
+	watcher.Watch(other)
+	terminated = <-resultChannel
+	assert.Equal(t, other, terminated.Actor)
+
+	// Unwatch
+	other = Actor.New(func(self *ActorDef[interface{}], input interface{}) {})
+	watcher.Watch(other)
+	watcher.Unwatch(other)
+	other.Stop()
+	<-other.Done()
+	assert.Equal(t, 0, len(resultChannel))
+}
+
+func TestActorWatchWith(t *testing.T) {
+	resultChannel := make(chan string, 1)
+
+	// Testee
+	watcher := ActorNewGenerics(func(self *ActorDef[string], input string) {
+		resultChannel <- input
+	})
+	target := ActorNewGenerics(func(self *ActorDef[int], input int) {})
+	watcher.WatchWith(target, func(terminated ActorTerminated) string {
+		return "terminated:" + terminated.Actor.GetName()
+	})
+	target.Stop()
+	assert.Equal(t, "terminated:"+target.GetName(), <-resultChannel)
+}
+
+func TestActorSubscribeTerminationConcurrently(t *testing.T) {
+	// Subscribing concurrently with the termination: every subscriber is notified once
+	for i := 0; i < 100; i++ {
+		actor := ActorNewGenerics(func(self *ActorDef[interface{}], input interface{}) {})
+		notified := make(chan ActorTerminated, 1)
+		go actor.Stop()
+		actor.SubscribeTermination(func(terminated ActorTerminated) {
+			notified <- terminated
+		})
+		<-actor.Done()
+		assert.Equal(t, ActorRef(actor), (<-notified).Actor)
+	}
+}
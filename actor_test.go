@@ -1,6 +1,8 @@
 package fpgo
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -41,8 +43,10 @@ func TestActorCommon(t *testing.T) {
 			}
 			// SHUTDOWN: for ROOT
 			if input == cmdShutdown {
-				for _, child := range self.children {
+				// Send() won't wait for children, wait for their termination before closing resultChannel
+				for _, child := range self.GetChildren() {
 					child.Send(cmdShutdown)
+					<-child.Done()
 				}
 				self.Close()
 
@@ -135,3 +139,285 @@ func TestActorAsk(t *testing.T) {
 	assert.Equal(t, expectedInt, actual)
 	assert.Equal(t, ErrActorAskTimeout, err)
 }
+
+func TestActorAskWithContext(t *testing.T) {
+	var actual int
+	var err error
+	var ctx context.Context
+	var cancel context.CancelFunc
+	replied := make(chan string, 1)
+
+	// Testee
+	actorRoot := ActorNewGenerics(func(self *ActorDef[*AskDef[int, int]], ask *AskDef[int, int]) {
+		// NOTE If negative, reply lately for testing Ask.timeout
+		if ask.Message < 0 {
+			time.Sleep(20 * time.Millisecond)
+			ask.Reply(ask.Message)
+			replied <- ask.CorrelationID
+			return
+		}
+
+		ask.Reply(ask.Message * 10)
+	})
+
+	// Normal cases
+	actual, err = AskNewGenerics[int, int](1).AskWithContext(context.Background(), actorRoot)
+	assert.Equal(t, 10, actual)
+	assert.Equal(t, nil, err)
+	// MonadIO
+	response := AskNewGenerics[int, int](2).AskIO(context.Background(), actorRoot).Eval()
+	assert.Equal(t, 20, response.Response)
+	assert.Equal(t, nil, response.Err)
+
+	// Timeout cases: the late reply should be dropped safely
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ask := AskNewGenerics[int, int](-1)
+	actual, err = ask.AskWithContext(ctx, actorRoot)
+	assert.Equal(t, 0, actual)
+	assert.Equal(t, ErrActorAskTimeout, err)
+	assert.Equal(t, ask.CorrelationID, <-replied)
+	assert.Equal(t, true, ask.IsDone())
+
+	// Cancel cases
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = AskNewGenerics[int, int](-2).AskWithContext(ctx, actorRoot)
+	assert.Equal(t, context.Canceled, err)
+	<-replied
+
+	// Correlation IDs
+	assert.NotEqual(t, AskNewGenerics[int, int](1).CorrelationID, AskNewGenerics[int, int](1).CorrelationID)
+}
+
+func TestActorBecome(t *testing.T) {
+	resultChannel := make(chan string, 1)
+	cmdConnect := "connect"
+	cmdDrain := "drain"
+	cmdBack := "back"
+	cmdState := "state"
+
+	var draining func(*ActorDef[string], string)
+	connected := func(self *ActorDef[string], input string) {
+		switch input {
+		case cmdDrain:
+			self.Become(draining, false)
+		case cmdBack:
+			self.Unbecome()
+		case cmdState:
+			resultChannel <- "connected"
+		}
+	}
+	draining = func(self *ActorDef[string], input string) {
+		switch input {
+		case cmdBack:
+			self.Unbecome()
+		case cmdState:
+			resultChannel <- "draining"
+		}
+	}
+	// Testee
+	actorRoot := ActorNewGenerics(func(self *ActorDef[string], input string) {
+		switch input {
+		case cmdConnect:
+			self.Become(connected, false)
+		case cmdState:
+			resultChannel <- "connecting"
+		}
+	})
+
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "connecting", <-resultChannel)
+	actorRoot.Send(cmdConnect)
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "connected", <-resultChannel)
+	actorRoot.Send(cmdDrain)
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "draining", <-resultChannel)
+	actorRoot.Send(cmdBack)
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "connected", <-resultChannel)
+	actorRoot.Send(cmdBack)
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "connecting", <-resultChannel)
+	// Unbecome on the empty stack should keep the original effect
+	actorRoot.Send(cmdBack)
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "connecting", <-resultChannel)
+
+	// discardOld
+	actorRoot.Send(cmdConnect)
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "connected", <-resultChannel)
+	actorRoot.Send(cmdDrain)
+	actorRoot.Send(cmdBack)
+	actorRoot.Send(cmdState)
+	assert.Equal(t, "connected", <-resultChannel)
+}
+
+func TestActorLifecycle(t *testing.T) {
+	var eventsM sync.Mutex
+	var events []string
+	record := func(event string) {
+		eventsM.Lock()
+		events = append(events, event)
+		eventsM.Unlock()
+	}
+	gate := make(chan struct{})
+	started := make(chan struct{})
+
+	// Testee
+	actorRoot := ActorNewWithOptionGenerics(func(self *ActorDef[string], input string) {
+		if input == "spawn" {
+			self.SpawnWithOption(func(self *ActorDef[string], input string) {}, &ActorOption[string]{
+				PreStart: func(self *ActorDef[string]) {
+					record("child PreStart")
+				},
+				PostStop: func(self *ActorDef[string]) {
+					record("child PostStop")
+				},
+			})
+			return
+		}
+		if input == "wait" {
+			close(started)
+			<-gate
+			return
+		}
+		record(input)
+	}, &ActorOption[string]{
+		PreStart: func(self *ActorDef[string]) {
+			record("PreStart")
+		},
+		PostStop: func(self *ActorDef[string]) {
+			record("PostStop")
+		},
+		StopPolicy: ActorStopDrain,
+	})
+	actorRoot.Send("spawn")
+	actorRoot.Send("wait")
+	<-started
+	actorRoot.Send("1")
+	actorRoot.Send("2")
+	actorRoot.Stop()
+	assert.Equal(t, true, actorRoot.IsClosed())
+	assert.Equal(t, ErrActorClosed, actorRoot.TrySend("3"))
+	close(gate)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Equal(t, nil, actorRoot.AwaitTermination(ctx))
+	eventsM.Lock()
+	defer eventsM.Unlock()
+	// Queued messages are drained, and children are stopped before PostStop
+	assert.ElementsMatch(t, []string{"PreStart", "child PreStart", "1", "2", "child PostStop", "PostStop"}, events)
+	assert.Equal(t, "PreStart", events[0])
+	assert.Equal(t, []string{"child PostStop", "PostStop"}, events[4:])
+}
+
+func TestActorLifecycleFailures(t *testing.T) {
+	// Panics of PreStart are handled by the Supervisor: stopped by the failure
+	actor := ActorNewWithOptionGenerics(func(self *ActorDef[string], input string) {}, &ActorOption[string]{
+		PreStart: func(self *ActorDef[string]) {
+			panic("PreStart")
+		},
+	})
+	<-actor.Done()
+	var terminated ActorTerminated
+	actor.SubscribeTermination(func(event ActorTerminated) {
+		terminated = event
+	})
+	assert.Equal(t, "PreStart", terminated.Cause)
+	assert.Equal(t, uint64(1), actor.GetMetrics().Failures)
+
+	// Panics of PreStart are handled by the Supervisor: resumed
+	received := make(chan string, 1)
+	actor = ActorNewWithOptionGenerics(func(self *ActorDef[string], input string) {
+		received <- input
+	}, &ActorOption[string]{
+		Supervisor: SupervisorStrategy.OneForOne(-1, 0, func(cause interface{}) SupervisorDirective {
+			return SupervisorResume
+		}),
+		PreStart: func(self *ActorDef[string]) {
+			panic("PreStart")
+		},
+	})
+	actor.Send("alive")
+	assert.Equal(t, "alive", <-received)
+	actor.Stop()
+	<-actor.Done()
+
+	// Panics of PostStop are reported as the cause
+	actor = ActorNewWithOptionGenerics(func(self *ActorDef[string], input string) {}, &ActorOption[string]{
+		PostStop: func(self *ActorDef[string]) {
+			panic("PostStop")
+		},
+	})
+	actor.Stop()
+	<-actor.Done()
+	actor.SubscribeTermination(func(event ActorTerminated) {
+		terminated = event
+	})
+	assert.Equal(t, "PostStop", terminated.Cause)
+}
+
+func TestActorStopDiscard(t *testing.T) {
+	var actual []int
+	gate := make(chan struct{})
+	started := make(chan struct{})
+
+	// Testee
+	actorRoot := ActorNewGenerics(func(self *ActorDef[int], input int) {
+		if input == 0 {
+			close(started)
+			<-gate
+			return
+		}
+		actual = append(actual, input)
+	})
+	actorRoot.Send(0)
+	<-started
+	actorRoot.Send(1)
+	actorRoot.Send(2)
+
+	// Concurrent Send/Close should be safe
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			actorRoot.Send(i + 10)
+			actorRoot.Close()
+		}(i)
+	}
+	wg.Wait()
+	close(gate)
+
+	<-actorRoot.Done()
+	assert.Equal(t, 0, len(actual))
+}
+
+func TestActorIOChannel(t *testing.T) {
+	var actual []int
+	ioCh := make(chan int)
+
+	// Testee
+	actorRoot := ActorNewByOptionsGenerics(func(self *ActorDef[int], input int) {
+		actual = append(actual, input)
+		if input == 2 {
+			self.Stop()
+		}
+	}, &ioCh, map[string]interface{}{})
+	ioCh <- 1
+	ioCh <- 2
+	<-actorRoot.Done()
+	assert.Equal(t, []int{1, 2}, actual)
+
+	// ioCh is owned by the caller: not closed by the Actor, & not read after its termination
+	select {
+	case ioCh <- 3:
+		assert.Fail(t, "ioCh is read after the termination")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(ioCh)
+}
@@ -0,0 +1,170 @@
+package actortest
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	fpgo "github.com/TeaEntityLab/fpGo"
+)
+
+// TestKit of Actors inspired by Akka TestKit
+
+const (
+	// DefaultTimeout Default timeout of expectations
+	DefaultTimeout = 3 * time.Second
+)
+
+var (
+	ErrTestProbeNoSender = fmt.Errorf("ErrTestProbeNoSender")
+)
+
+// TestingT The subset of testing.TB used by expectations
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// TestMessage A message received by TestProbe with its sender
+type TestMessage[T any] struct {
+	Message T
+	// Sender The sender of the message (nil: unknown)
+	Sender fpgo.ActorRef
+}
+
+// TestProbeDef[T] TestProbe, an Actor queueing the received messages for expectations
+//
+// It could be the sender of messages(Tell(message, probe)), and Reply() to the last sender.
+type TestProbeDef[T any] struct {
+	*fpgo.ActorDef[T]
+
+	timeout    time.Duration
+	received   []TestMessage[T]
+	lastSender fpgo.ActorRef
+	receivedM  sync.Mutex
+	notify     chan struct{}
+}
+
+// TestProbeNewGenerics New a TestProbe (its Actor uses ActorDispatcherCallingThread)
+func TestProbeNewGenerics[T any]() *TestProbeDef[T] {
+	newOne := &TestProbeDef[T]{
+		timeout: DefaultTimeout,
+		notify:  make(chan struct{}, 1),
+	}
+	newOne.ActorDef = fpgo.ActorNewWithOptionGenerics(func(self *fpgo.ActorDef[T], message T) {
+		newOne.receivedM.Lock()
+		newOne.received = append(newOne.received, TestMessage[T]{Message: message, Sender: self.GetSender()})
+		newOne.receivedM.Unlock()
+
+		select {
+		case newOne.notify <- struct{}{}:
+		default:
+		}
+	}, &fpgo.ActorOption[T]{Dispatcher: fpgo.ActorDispatcherCallingThread})
+
+	return newOne
+}
+
+// SetTimeout Set the timeout of expectations (DefaultTimeout by default)
+func (probeSelf *TestProbeDef[T]) SetTimeout(timeout time.Duration) *TestProbeDef[T] {
+	probeSelf.timeout = timeout
+	return probeSelf
+}
+
+// ReceiveMsg Take the next received message, waiting until the timeout (ok is false if timed out)
+func (probeSelf *TestProbeDef[T]) ReceiveMsg(timeout time.Duration) (TestMessage[T], bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		probeSelf.receivedM.Lock()
+		if len(probeSelf.received) > 0 {
+			received := probeSelf.received[0]
+			probeSelf.received = probeSelf.received[1:]
+			probeSelf.lastSender = received.Sender
+			probeSelf.receivedM.Unlock()
+			return received, true
+		}
+		probeSelf.receivedM.Unlock()
+
+		select {
+		case <-probeSelf.notify:
+		case <-timer.C:
+			return TestMessage[T]{}, false
+		}
+	}
+}
+
+// ExpectMsg Expect the next message equals to expected(reflect.DeepEqual) within the timeout
+func (probeSelf *TestProbeDef[T]) ExpectMsg(t TestingT, expected T) T {
+	t.Helper()
+
+	received, ok := probeSelf.ReceiveMsg(probeSelf.timeout)
+	if !ok {
+		t.Errorf("timeout (%v) while expecting message %#v", probeSelf.timeout, expected)
+		return received.Message
+	}
+	if !reflect.DeepEqual(expected, received.Message) {
+		t.Errorf("expected message %#v, but got %#v", expected, received.Message)
+	}
+	return received.Message
+}
+
+// ExpectNoMsg Expect no messages received within the duration
+func (probeSelf *TestProbeDef[T]) ExpectNoMsg(t TestingT, duration time.Duration) {
+	t.Helper()
+
+	received, ok := probeSelf.ReceiveMsg(duration)
+	if ok {
+		t.Errorf("expected no message, but got %#v", received.Message)
+	}
+}
+
+// ExpectMsgTypeGenerics Expect the next message is M within the timeout, and return it
+func ExpectMsgTypeGenerics[M any, T any](t TestingT, probe *TestProbeDef[T]) M {
+	t.Helper()
+
+	var typed M
+	received, ok := probe.ReceiveMsg(probe.timeout)
+	if !ok {
+		t.Errorf("timeout (%v) while expecting message of %T", probe.timeout, typed)
+		return typed
+	}
+	typed, ok = interface{}(received.Message).(M)
+	if !ok {
+		t.Errorf("expected message of %T, but got %#v", typed, received.Message)
+	}
+	return typed
+}
+
+// GetLastSender Get the sender of the last message taken by expectations/ReceiveMsg (nil: unknown)
+func (probeSelf *TestProbeDef[T]) GetLastSender() fpgo.ActorRef {
+	probeSelf.receivedM.Lock()
+	defer probeSelf.receivedM.Unlock()
+	return probeSelf.lastSender
+}
+
+// Reply Send the message to the last sender, the TestProbe is the sender
+func (probeSelf *TestProbeDef[T]) Reply(message interface{}) error {
+	sender := probeSelf.GetLastSender()
+	if sender == nil {
+		return ErrTestProbeNoSender
+	}
+
+	return sender.TellForInterface(message, probeSelf)
+}
+
+// Intercept Forward messages sent to the target(e.g. a child Actor) to the TestProbe
+//
+// The target still receives them if passThrough.
+func (probeSelf *TestProbeDef[T]) Intercept(target *fpgo.ActorDef[T], passThrough bool) {
+	target.SetInterceptor(func(message T, sender fpgo.ActorRef) bool {
+		probeSelf.Tell(message, sender)
+		return passThrough
+	})
+}
+
+// StopIntercepting Stop forwarding messages sent to the target
+func (probeSelf *TestProbeDef[T]) StopIntercepting(target *fpgo.ActorDef[T]) {
+	target.SetInterceptor(nil)
+}
@@ -0,0 +1,113 @@
+package actortest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	fpgo "github.com/TeaEntityLab/fpGo"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (fakeSelf *fakeT) Helper() {}
+func (fakeSelf *fakeT) Errorf(format string, args ...interface{}) {
+	fakeSelf.errors = append(fakeSelf.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTestProbe(t *testing.T) {
+	probe := TestProbeNewGenerics[string]().SetTimeout(time.Second)
+	echo := fpgo.ActorNewGenerics(func(self *fpgo.ActorDef[string], input string) {
+		self.GetSender().TellForInterface(strings.ToUpper(input), self)
+	})
+	defer echo.Stop()
+
+	// As the sender
+	echo.Tell("hello", probe)
+	assert.Equal(t, "HELLO", probe.ExpectMsg(t, "HELLO"))
+	assert.Equal(t, fpgo.ActorRef(echo), probe.GetLastSender())
+	probe.ExpectNoMsg(t, 10*time.Millisecond)
+
+	// Reply to the last sender
+	assert.Equal(t, nil, probe.Reply("again"))
+	probe.ExpectMsg(t, "AGAIN")
+
+	// Failed expectations
+	failed := &fakeT{}
+	probe.SetTimeout(10*time.Millisecond).ExpectMsg(failed, "nothing")
+	probe.Send("unexpected")
+	probe.ExpectMsg(failed, "expected")
+	probe.Send("unexpected")
+	probe.ExpectNoMsg(failed, 10*time.Millisecond)
+	assert.Equal(t, 3, len(failed.errors))
+
+	// Types
+	anyProbe := TestProbeNewGenerics[interface{}]().SetTimeout(10 * time.Millisecond)
+	anyProbe.Send(1)
+	assert.Equal(t, 1, ExpectMsgTypeGenerics[int](t, anyProbe))
+	anyProbe.Send("1")
+	ExpectMsgTypeGenerics[int](failed, anyProbe)
+	assert.Equal(t, 4, len(failed.errors))
+	assert.Equal(t, ErrTestProbeNoSender, anyProbe.Reply(1))
+}
+
+func TestTestProbeIntercept(t *testing.T) {
+	probe := TestProbeNewGenerics[string]()
+	var worked []string
+	parent := fpgo.ActorNewGenerics(func(self *fpgo.ActorDef[string], input string) {
+		if input == "spawn" {
+			self.SpawnNamed("worker", func(self *fpgo.ActorDef[string], input string) {
+				worked = append(worked, input)
+			}, nil)
+			return
+		}
+		self.GetChildByName("worker").Tell(input, self)
+	})
+	defer parent.Stop()
+
+	parent.Send("spawn")
+	var worker *fpgo.ActorDef[string]
+	for worker == nil {
+		worker = parent.GetChildByName("worker")
+	}
+
+	// Swallowed by the TestProbe
+	probe.Intercept(worker, false)
+	parent.Send("job 1")
+	probe.ExpectMsg(t, "job 1")
+	assert.Equal(t, fpgo.ActorRef(parent), probe.GetLastSender())
+	probe.StopIntercepting(worker)
+	assert.Equal(t, 0, len(worked))
+}
+
+func TestActorDispatcherCallingThread(t *testing.T) {
+	var received []int
+	actor := fpgo.ActorNewWithOptionGenerics(func(self *fpgo.ActorDef[int], input int) {
+		received = append(received, input)
+		if input == 1 {
+			// Processed after the current one
+			self.Send(2)
+			received = append(received, -1)
+		}
+	}, &fpgo.ActorOption[int]{
+		Dispatcher: fpgo.ActorDispatcherCallingThread,
+		PostStop: func(self *fpgo.ActorDef[int]) {
+			received = append(received, 0)
+		},
+	})
+
+	// No waiting: processed before Send returns
+	actor.Send(1)
+	assert.Equal(t, []int{1, -1, 2}, received)
+	actor.Send(3)
+	assert.Equal(t, []int{1, -1, 2, 3}, received)
+	actor.Stop()
+	assert.Equal(t, true, actor.IsClosed())
+	assert.Equal(t, []int{1, -1, 2, 3, 0}, received)
+	<-actor.Done()
+}
@@ -1,10 +1,17 @@
 package fpgo
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 )
 
+var (
+	ErrCorDone   = fmt.Errorf("ErrCorDone")
+	ErrCorClosed = fmt.Errorf("ErrCorClosed")
+)
+
 // AtomBool Atomic Bool
 type AtomBool struct{ flag int32 }
 
@@ -26,22 +33,65 @@ func (atomBoolSelf *AtomBool) Get() bool {
 	return false
 }
 
+// CompareAndSwap Swap the bool atomically if it's the old one
+func (atomBoolSelf *AtomBool) CompareAndSwap(old bool, new bool) bool {
+	var oldFlag, newFlag int32
+	if old {
+		oldFlag = 1
+	}
+	if new {
+		newFlag = 1
+	}
+	return atomic.CompareAndSwapInt32(&(atomBoolSelf.flag), oldFlag, newFlag)
+}
+
+// CorPanicError The panic of the effect of a Cor, reported by Err() & re-raised at the YieldFrom call site
+type CorPanicError struct {
+	Cause interface{}
+}
+
+// Error Show the panic cause
+func (errSelf CorPanicError) Error() string {
+	return fmt.Sprintf("CorPanicError: %v", errSelf.Cause)
+}
+
+// corUnwind Unwind the effect from a yield point(recovered by the Cor goroutine, deferred calls run)
+type corUnwind struct {
+	err error
+}
+
+func (unwindSelf corUnwind) Error() string {
+	return unwindSelf.err.Error()
+}
+func (unwindSelf corUnwind) Unwrap() error {
+	return unwindSelf.err
+}
+
 // CorOp Cor Yield Operation/Delegation/Callback
 type CorOp[T any] struct {
 	cor *CorDef[T]
 	val T
+	// err The error thrown into the yield point
+	err error
 }
 
 // CorDef Cor Coroutine inspired by Python/Ecmascript/Lua
 type CorDef[T any] struct {
 	isStarted AtomBool
 	isClosed  AtomBool
+	isClosing AtomBool
 	closedM   sync.Mutex
+	done      chan struct{}
+	err       error
+	errM      sync.RWMutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	opCh     *chan *CorOp[T]
 	resultCh *chan T
 
-	effect func()
+	effect func() error
 }
 
 // New New a Cor instance
@@ -49,11 +99,28 @@ func (corSelf *CorDef[T]) New(effect func()) *CorDef[interface{}] {
 	return CorNewGenerics[interface{}](effect)
 }
 
+// NewWithContext New a Cor instance with the context & an effect returning the error
+func (corSelf *CorDef[T]) NewWithContext(ctx context.Context, effect func() error) *CorDef[interface{}] {
+	return CorNewWithContextGenerics[interface{}](ctx, effect)
+}
+
 // New New a Cor instance
 func CorNewGenerics[T any](effect func()) *CorDef[T] {
+	return CorNewWithContextGenerics[T](context.Background(), func() error {
+		effect()
+		return nil
+	})
+}
+
+// CorNewWithContextGenerics New a Cor instance with the context & an effect returning the error
+//
+// Cancelling the ctx(or calling Cancel()) unwinds the effect from its yield points & unblocks the parties waiting for it.
+// Panics/returned errors of the effect are reported by Err() & propagated to YieldFrom call sites.
+func CorNewWithContextGenerics[T any](ctx context.Context, effect func() error) *CorDef[T] {
 	opCh := make(chan *CorOp[T], 5)
 	resultCh := make(chan T, 5)
-	cor := &CorDef[T]{effect: effect, opCh: &opCh, resultCh: &resultCh, isStarted: AtomBool{flag: 0}}
+	cor := &CorDef[T]{effect: effect, opCh: &opCh, resultCh: &resultCh, isStarted: AtomBool{flag: 0}, done: make(chan struct{})}
+	cor.ctx, cor.cancel = context.WithCancel(ctx)
 	return cor
 }
 
@@ -65,20 +132,39 @@ func (corSelf *CorDef[T]) NewAndStart(effect func()) *CorDef[T] {
 }
 
 // DoNotation Do Notation by function (inspired by Haskell one)
+//
+// Panics of the effect are re-raised here, and so are errors (e.g. returned by the targets of YieldFrom()) by panic(err).
 func (corSelf *CorDef[T]) DoNotation(effect func(*CorDef[T]) T) T {
+	result, err := corSelf.DoNotationWithContext(context.Background(), func(cor *CorDef[T]) (T, error) {
+		return effect(cor), nil
+	})
+	if panicErr, ok := err.(CorPanicError); ok {
+		panic(panicErr.Cause)
+	} else if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// DoNotationWithContext Do Notation by function with the context, returning the panic(CorPanicError)/returned error of the effect
+func (corSelf *CorDef[T]) DoNotationWithContext(ctx context.Context, effect func(*CorDef[T]) (T, error)) (T, error) {
 	var result T
 
-	var wg sync.WaitGroup
-	wg.Add(1)
 	var cor *CorDef[T]
-	cor = CorNewGenerics[T](func() {
-		result = effect(cor)
-		wg.Done()
+	cor = CorNewWithContextGenerics[T](ctx, func() error {
+		var err error
+		result, err = effect(cor)
+		return err
 	})
 	cor.Start()
-	wg.Wait()
-
-	return result
+	select {
+	case <-cor.done:
+		return result, cor.Err()
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
 }
 
 // StartWithVal Start the Cor with an initial value
@@ -93,86 +179,173 @@ func (corSelf *CorDef[T]) StartWithVal(in T) {
 
 // Start Start the Cor
 func (corSelf *CorDef[T]) Start() {
-	if corSelf.IsDone() || corSelf.isStarted.Get() {
+	if corSelf.IsDone() || !corSelf.isStarted.CompareAndSwap(false, true) {
 		return
 	}
-	corSelf.isStarted.Set(true)
 
 	go func() {
-		corSelf.effect()
-		corSelf.close()
+		defer corSelf.close()
+		corSelf.setErr(runCorEffect(corSelf.effect))
 	}()
 }
 
+// runCorEffect Run the effect of a Cor, returning its error, the panic(CorPanicError) or the error unwinding it
+func runCorEffect(effect func() error) (err error) {
+	defer func() {
+		if cause := recover(); cause != nil {
+			if unwind, ok := cause.(corUnwind); ok {
+				err = unwind.err
+				return
+			}
+			err = CorPanicError{Cause: cause}
+		}
+	}()
+
+	return effect()
+}
+
 // // Yield Yield back(nil)
 // func (corSelf *CorDef[T]) Yield() T {
 // 	return corSelf.YieldRef(nil)
 // }
 
 // YieldRef Yield a value
+//
+// If the Cor is cancelled/closed or an error is thrown into it, the effect unwinds from here.
 func (corSelf *CorDef[T]) YieldRef(out T) T {
+	result, err := corSelf.YieldRefWithErr(out)
+	if err != nil && err != ErrCorDone {
+		panic(corUnwind{err: err})
+	}
+
+	return result
+}
+
+// YieldRefWithErr Yield a value, returning the error thrown into it, ErrCorClosed if the Cor is closed, or the ctx error if it's cancelled
+func (corSelf *CorDef[T]) YieldRefWithErr(out T) (T, error) {
 	var result T
 	if corSelf.IsDone() {
-		return result
+		return result, ErrCorDone
+	}
+	if corSelf.ctx.Err() != nil {
+		return result, corSelf.ctxErr()
 	}
 
 	var op *CorOp[T]
-	var more bool
 	// fmt.Println(corSelf, "Wait for", "op")
-	op, more = <-*corSelf.opCh
+	select {
+	case op = <-*corSelf.opCh:
+	case <-corSelf.ctx.Done():
+		return result, corSelf.ctxErr()
+	}
 	// fmt.Println(corSelf, "Wait for", "op", "done")
+	if op == nil {
+		return result, ErrCorDone
+	}
+	if op.err != nil {
+		return result, op.err
+	}
 
-	if more && op != nil && op.cor != nil {
+	if op.cor != nil {
 		cor := op.cor
 		cor.doCloseSafe(func() {
-			*cor.resultCh <- out
+			select {
+			case *cor.resultCh <- out:
+			case <-cor.ctx.Done():
+			}
 		})
 	}
 	result = op.val
 
-	return result
+	return result, nil
 }
 
 // YieldFrom Yield from a given Cor
+//
+// The panic/returned error of the target is re-raised here, and the effect unwinds from here if this Cor is cancelled.
 func (corSelf *CorDef[T]) YieldFrom(target *CorDef[T], in T) T {
+	result, err := corSelf.YieldFromWithErr(target, in)
+	if err != nil && err != ErrCorDone {
+		panic(corUnwind{err: err})
+	}
+
+	return result
+}
+
+// YieldFromWithErr Yield from a given Cor, returning the error instead of re-raising it
+//
+// Errors: the panic(CorPanicError)/returned error of the target, ErrCorDone if it's done(or closed) without yielding,
+// or ErrCorClosed/the ctx error of this Cor.
+func (corSelf *CorDef[T]) YieldFromWithErr(target *CorDef[T], in T) (T, error) {
 	var result T
 	if corSelf.IsDone() {
-		return result
+		return result, ErrCorDone
+	}
+	if corSelf.ctx.Err() != nil {
+		return result, corSelf.ctxErr()
 	}
 
 	target.receive(corSelf, in)
 
 	// fmt.Println(corSelf, "Wait for", "result")
-	result, _ = <-*corSelf.resultCh
+	select {
+	case result = <-*corSelf.resultCh:
+		return result, nil
+	case <-corSelf.ctx.Done():
+		return result, corSelf.ctxErr()
+	case <-target.done:
+	}
 	// fmt.Println(corSelf, "Wait for", "result", "done")
 
-	return result
+	// The target could yield right before it's done
+	select {
+	case result = <-*corSelf.resultCh:
+		return result, nil
+	default:
+	}
+	if err := target.Err(); err != nil && err != ErrCorClosed {
+		return result, err
+	}
+	return result, ErrCorDone
 }
 func (corSelf *CorDef[T]) receive(cor *CorDef[T], in T) {
 	corSelf.doCloseSafe(func() {
 		if corSelf.opCh != nil {
+			var cancelled <-chan struct{}
+			if cor != nil {
+				cancelled = cor.ctx.Done()
+			}
 			// fmt.Println(corSelf, "Wait for", "receive", cor, in)
-			*(corSelf.opCh) <- &CorOp[T]{cor: cor, val: in}
+			select {
+			case *(corSelf.opCh) <- &CorOp[T]{cor: cor, val: in}:
+			case <-corSelf.done:
+			case <-cancelled:
+			}
 			// fmt.Println(corSelf, "Wait for", "receive", "done")
 		}
 	})
 }
 
 // YieldFromIO Yield from a given MonadIO
+//
+// If the Cor is cancelled, the effect unwinds from here.
 func (corSelf *CorDef[T]) YieldFromIO(target *MonadIODef[T]) T {
-	var result T
-
-	var wg sync.WaitGroup
-	wg.Add(1)
+	resultCh := make(chan T, 1)
 	target.SubscribeOn(nil).Subscribe(Subscription[T]{
 		OnNext: func(in T) {
-			result = in
-			wg.Done()
+			select {
+			case resultCh <- in:
+			default:
+			}
 		},
 	})
-	wg.Wait()
 
-	return result
+	select {
+	case result := <-resultCh:
+		return result
+	case <-corSelf.ctx.Done():
+		panic(corUnwind{err: corSelf.ctxErr()})
+	}
 }
 
 // IsDone Is the Cor done
@@ -184,8 +357,77 @@ func (corSelf *CorDef[T]) IsDone() bool {
 func (corSelf *CorDef[T]) IsStarted() bool {
 	return corSelf.isStarted.Get()
 }
+
+// Done Get the channel closed when the Cor is done
+func (corSelf *CorDef[T]) Done() <-chan struct{} {
+	return corSelf.done
+}
+
+// Err Get the error of the Cor: the panic(CorPanicError)/returned error of the effect, or the ctx error if it's cancelled at a yield point
+func (corSelf *CorDef[T]) Err() error {
+	corSelf.errM.RLock()
+	defer corSelf.errM.RUnlock()
+	return corSelf.err
+}
+func (corSelf *CorDef[T]) setErr(err error) {
+	corSelf.errM.Lock()
+	corSelf.err = err
+	corSelf.errM.Unlock()
+}
+
+// GetContext Get the context of the Cor (cancelled by Cancel() or when the Cor is done)
+func (corSelf *CorDef[T]) GetContext() context.Context {
+	return corSelf.ctx
+}
+
+// Cancel Cancel the Cor, its effect unwinds from the yield points(deferred calls run) & the parties waiting for it are unblocked
+func (corSelf *CorDef[T]) Cancel() {
+	corSelf.cancel()
+}
+
+// Throw Throw the error into the suspended YieldRef (inspired by Python generator.throw())
+//
+// YieldRef unwinds the effect by the error(reported by Err()), and YieldRefWithErr returns it to let the effect handle it.
+func (corSelf *CorDef[T]) Throw(err error) {
+	corSelf.doCloseSafe(func() {
+		select {
+		case *(corSelf.opCh) <- &CorOp[T]{err: err}:
+		case <-corSelf.done:
+		}
+	})
+}
+
+// Close Close the Cor & wait for its effect unwinding from the yield points by ErrCorClosed(deferred calls run)
+//
+// If the Cor isn't started, the effect won't run. It returns the error of the effect other than ErrCorClosed.
+// Don't call it in the effect of the Cor itself, it would wait forever.
+func (corSelf *CorDef[T]) Close() error {
+	corSelf.isClosing.Set(true)
+	if corSelf.isStarted.CompareAndSwap(false, true) {
+		corSelf.close()
+		return nil
+	}
+
+	corSelf.cancel()
+	<-corSelf.done
+	if err := corSelf.Err(); err != ErrCorClosed {
+		return err
+	}
+	return nil
+}
+
+// ctxErr The error for the done ctx: ErrCorClosed if it's closed by Close()
+func (corSelf *CorDef[T]) ctxErr() error {
+	if corSelf.isClosing.Get() {
+		return ErrCorClosed
+	}
+
+	return corSelf.ctx.Err()
+}
 func (corSelf *CorDef[T]) close() {
 	corSelf.isClosed.Set(true)
+	corSelf.cancel()
+	close(corSelf.done)
 
 	corSelf.closedM.Lock()
 	if corSelf.resultCh != nil {
@@ -197,12 +439,12 @@ func (corSelf *CorDef[T]) close() {
 	corSelf.closedM.Unlock()
 }
 func (corSelf *CorDef[T]) doCloseSafe(fn func()) {
+	corSelf.closedM.Lock()
+	defer corSelf.closedM.Unlock()
 	if corSelf.IsDone() {
 		return
 	}
-	corSelf.closedM.Lock()
 	fn()
-	corSelf.closedM.Unlock()
 }
 
 // Cor Cor utils instance
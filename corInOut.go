@@ -0,0 +1,189 @@
+package fpgo
+
+import (
+	"context"
+	"sync"
+)
+
+// CorInOutDef Cor with different types of sent(In) & yielded(Out) values, inspired by Python generator.send()
+//
+// Each Send() resumes the effect with an In (the first one is the argument of the effect, the others are returned by Yield()),
+// and gets the next Out yielded by it (ErrCorDone if the effect returns instead).
+type CorInOutDef[In any, Out any] struct {
+	isStarted AtomBool
+	startOnce sync.Once
+	sendM     sync.Mutex
+	done      chan struct{}
+	err       error
+	errM      sync.RWMutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	inCh  chan In
+	outCh chan Out
+
+	effect func(*CorInOutDef[In, Out], In) error
+}
+
+// CorInOutNewGenerics New a CorInOut instance
+func CorInOutNewGenerics[In any, Out any](effect func(self *CorInOutDef[In, Out], in In)) *CorInOutDef[In, Out] {
+	return CorInOutNewWithContextGenerics(context.Background(), func(self *CorInOutDef[In, Out], in In) error {
+		effect(self, in)
+		return nil
+	})
+}
+
+// CorInOutNewWithContextGenerics New a CorInOut instance with the context & an effect returning the error
+//
+// Like CorDef, cancelling unwinds the effect from Yield() & panics/returned errors are reported to Send() callers.
+func CorInOutNewWithContextGenerics[In any, Out any](ctx context.Context, effect func(self *CorInOutDef[In, Out], in In) error) *CorInOutDef[In, Out] {
+	cor := &CorInOutDef[In, Out]{effect: effect, inCh: make(chan In), outCh: make(chan Out), done: make(chan struct{})}
+	cor.ctx, cor.cancel = context.WithCancel(ctx)
+	return cor
+}
+
+// Send Resume the effect with the value(the first one starts it) & get the next yielded value
+func (corSelf *CorInOutDef[In, Out]) Send(in In) (Out, error) {
+	return corSelf.SendWithContext(context.Background(), in)
+}
+
+// SendWithContext Send with the context of the caller
+//
+// If the ctx is done after the value is received by the effect, the Cor is cancelled(the yielded value would be lost).
+func (corSelf *CorInOutDef[In, Out]) SendWithContext(ctx context.Context, in In) (Out, error) {
+	corSelf.sendM.Lock()
+	defer corSelf.sendM.Unlock()
+
+	var out Out
+	if corSelf.IsDone() {
+		return out, corSelf.doneErr()
+	}
+	isFirst := false
+	corSelf.startOnce.Do(func() {
+		isFirst = true
+		corSelf.start(in)
+	})
+	if !isFirst {
+		select {
+		case corSelf.inCh <- in:
+		case <-corSelf.done:
+			return out, corSelf.doneErr()
+		case <-ctx.Done():
+			return out, ctx.Err()
+		}
+	}
+
+	select {
+	case out = <-corSelf.outCh:
+		return out, nil
+	case <-corSelf.done:
+		return out, corSelf.doneErr()
+	case <-ctx.Done():
+		corSelf.Cancel()
+		return out, ctx.Err()
+	}
+}
+
+// Yield Yield the value to the Send() caller & get the next sent value
+//
+// If the Cor is cancelled, the effect unwinds from here.
+func (corSelf *CorInOutDef[In, Out]) Yield(out Out) In {
+	in, err := corSelf.YieldWithErr(out)
+	if err != nil {
+		panic(corUnwind{err: err})
+	}
+
+	return in
+}
+
+// YieldWithErr Yield the value, returning the ctx error if the Cor is cancelled
+func (corSelf *CorInOutDef[In, Out]) YieldWithErr(out Out) (In, error) {
+	var in In
+	select {
+	case corSelf.outCh <- out:
+	case <-corSelf.ctx.Done():
+		return in, corSelf.ctx.Err()
+	}
+
+	select {
+	case in = <-corSelf.inCh:
+		return in, nil
+	case <-corSelf.ctx.Done():
+		return in, corSelf.ctx.Err()
+	}
+}
+
+// CorYieldFromInOutGenerics Yield from a CorInOut by Send() in the effect of a Cor (e.g. DoNotation)
+//
+// Like YieldFrom(), the panic/returned error of the target is re-raised, and the effect unwinds if the Cor is cancelled.
+func CorYieldFromInOutGenerics[T any, In any, Out any](self *CorDef[T], target *CorInOutDef[In, Out], in In) Out {
+	out, err := target.SendWithContext(self.GetContext(), in)
+	if err != nil && err != ErrCorDone {
+		panic(corUnwind{err: err})
+	}
+
+	return out
+}
+
+// IsDone Is the CorInOut done
+func (corSelf *CorInOutDef[In, Out]) IsDone() bool {
+	select {
+	case <-corSelf.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsStarted Is the CorInOut started
+func (corSelf *CorInOutDef[In, Out]) IsStarted() bool {
+	return corSelf.isStarted.Get()
+}
+
+// Done Get the channel closed when the CorInOut is done
+func (corSelf *CorInOutDef[In, Out]) Done() <-chan struct{} {
+	return corSelf.done
+}
+
+// Err Get the error of the CorInOut: the panic(CorPanicError)/returned error of the effect, or the ctx error if it's cancelled at a yield point
+func (corSelf *CorInOutDef[In, Out]) Err() error {
+	corSelf.errM.RLock()
+	defer corSelf.errM.RUnlock()
+	return corSelf.err
+}
+
+// GetContext Get the context of the CorInOut (cancelled by Cancel() or when the CorInOut is done)
+func (corSelf *CorInOutDef[In, Out]) GetContext() context.Context {
+	return corSelf.ctx
+}
+
+// Cancel Cancel the CorInOut, its effect unwinds from Yield()(deferred calls run) & Send() callers are unblocked
+func (corSelf *CorInOutDef[In, Out]) Cancel() {
+	corSelf.cancel()
+}
+func (corSelf *CorInOutDef[In, Out]) start(in In) {
+	corSelf.isStarted.Set(true)
+
+	go func() {
+		defer corSelf.close()
+		err := runCorEffect(func() error {
+			return corSelf.effect(corSelf, in)
+		})
+
+		corSelf.errM.Lock()
+		corSelf.err = err
+		corSelf.errM.Unlock()
+	}()
+}
+func (corSelf *CorInOutDef[In, Out]) close() {
+	corSelf.cancel()
+	close(corSelf.done)
+}
+func (corSelf *CorInOutDef[In, Out]) doneErr() error {
+	if err := corSelf.Err(); err != nil {
+		return err
+	}
+
+	return ErrCorDone
+}
@@ -0,0 +1,96 @@
+package fpgo
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorInOutSend(t *testing.T) {
+	// A running total taking ints & yielding strings
+	cleaned := make(chan bool, 1)
+	summer := CorInOutNewGenerics(func(self *CorInOutDef[int, string], in int) {
+		defer func() {
+			cleaned <- true
+		}()
+
+		total := in
+		for in >= 0 {
+			in = self.Yield("total:" + strconv.Itoa(total))
+			total += in
+		}
+	})
+	assert.Equal(t, false, summer.IsStarted())
+
+	out, err := summer.Send(1)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "total:1", out)
+	out, err = summer.Send(2)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "total:3", out)
+	assert.Equal(t, true, summer.IsStarted())
+
+	// Returned without yielding
+	out, err = summer.Send(-1)
+	assert.Equal(t, ErrCorDone, err)
+	assert.Equal(t, "", out)
+	assert.Equal(t, true, <-cleaned)
+	assert.Equal(t, true, summer.IsDone())
+	_, err = summer.Send(1)
+	assert.Equal(t, ErrCorDone, err)
+
+	// Panics
+	failed := CorInOutNewGenerics(func(self *CorInOutDef[int, string], in int) {
+		self.Yield("ok")
+		panic("boom")
+	})
+	out, _ = failed.Send(0)
+	assert.Equal(t, "ok", out)
+	_, err = failed.Send(0)
+	assert.Equal(t, CorPanicError{Cause: "boom"}, err)
+
+	// Cancelled by the context of the caller: the effect unwinds from Yield
+	slow := CorInOutNewGenerics(func(self *CorInOutDef[int, string], in int) {
+		defer func() {
+			cleaned <- true
+		}()
+
+		<-self.GetContext().Done()
+		self.Yield("late")
+		cleaned <- false
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = slow.SendWithContext(ctx, 0)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, true, <-cleaned)
+	<-slow.Done()
+	assert.Equal(t, context.Canceled, slow.Err())
+}
+
+func TestCorInOutDoNotation(t *testing.T) {
+	doubler := CorInOutNewGenerics(func(self *CorInOutDef[int, string], in int) {
+		for {
+			in = self.Yield(strconv.Itoa(in * 2))
+		}
+	})
+	defer doubler.Cancel()
+
+	actual := Cor.DoNotation(func(self *CorDef[interface{}]) interface{} {
+		return CorYieldFromInOutGenerics(self, doubler, 1) + "," + CorYieldFromInOutGenerics(self, doubler, 21)
+	})
+	assert.Equal(t, "2,42", actual)
+
+	// Failures of the target are re-raised
+	failed := CorInOutNewGenerics(func(self *CorInOutDef[int, string], in int) {
+		panic("boom")
+	})
+	assert.PanicsWithValue(t, "boom", func() {
+		Cor.DoNotation(func(self *CorDef[interface{}]) interface{} {
+			return CorYieldFromInOutGenerics(self, failed, 1)
+		})
+	})
+}
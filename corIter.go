@@ -0,0 +1,65 @@
+package fpgo
+
+import (
+	"context"
+)
+
+// ToSeq Convert the Cor(a generator by YieldRef) into a sequence of the yielded values
+//
+// The result is an iter.Seq[T] of Go 1.23 (usable by range-over-func), without requiring Go 1.23 here.
+// Breaking the iteration early closes the Cor(deferred calls of the effect run),
+// and the error of the effect stops the iteration & is reported by Err().
+func (corSelf *CorDef[T]) ToSeq() func(yield func(T) bool) {
+	return corSelf.toSeq(context.Background())
+}
+
+// ToChannel Convert the Cor(a generator by YieldRef) into a receive-only channel of the yielded values, closed when it's done
+//
+// Cancelling the ctx closes the Cor & the channel, like breaking ToSeq() early.
+func (corSelf *CorDef[T]) ToChannel(ctx context.Context) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		corSelf.toSeq(ctx)(func(value T) bool {
+			select {
+			case ch <- value:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	return ch
+}
+
+// CorToStreamGenerics Collect the values yielded by the Cor(a generator by YieldRef) into a Stream
+func CorToStreamGenerics[T comparable](cor *CorDef[T]) *StreamDef[T] {
+	var list []T
+	cor.ToSeq()(func(value T) bool {
+		list = append(list, value)
+		return true
+	})
+
+	return StreamFromArray(list)
+}
+
+func (corSelf *CorDef[T]) toSeq(ctx context.Context) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		corSelf.Start()
+		// The Cor is done already unless the iteration is stopped early
+		defer corSelf.Close()
+		consumer := CorNewWithContextGenerics[T](ctx, func() error {
+			return nil
+		})
+		defer consumer.Close()
+
+		var in T
+		for {
+			value, err := consumer.YieldFromWithErr(corSelf, in)
+			if err != nil || !yield(value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+//go:build go1.23
+
+package fpgo
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorToSeqRangeOverFunc(t *testing.T) {
+	cleaned := make(chan bool, 1)
+
+	// ToSeq() is an iter.Seq[T]
+	var seq iter.Seq[int] = newCorCounter(3, cleaned).ToSeq()
+	var actual []int
+	for value := range seq {
+		actual = append(actual, value)
+	}
+	assert.Equal(t, []int{1, 2, 3}, actual)
+	assert.Equal(t, true, <-cleaned)
+
+	// Break early: the Cor is closed & the deferred calls run
+	actual = nil
+	cor := newCorCounter(100, cleaned)
+	for value := range cor.ToSeq() {
+		actual = append(actual, value)
+		if value == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2}, actual)
+	assert.Equal(t, true, <-cleaned)
+	assert.Equal(t, true, cor.IsDone())
+	assert.Equal(t, ErrCorClosed, cor.Err())
+
+	// iter.Pull works on it too
+	next, stop := iter.Pull(newCorCounter(100, cleaned).ToSeq())
+	value, ok := next()
+	assert.Equal(t, 1, value)
+	assert.Equal(t, true, ok)
+	stop()
+	assert.Equal(t, true, <-cleaned)
+}
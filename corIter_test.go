@@ -0,0 +1,91 @@
+package fpgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCorCounter(n int, cleaned chan bool) *CorDef[int] {
+	var cor *CorDef[int]
+	cor = CorNewGenerics[int](func() {
+		defer func() {
+			cleaned <- true
+		}()
+		for i := 1; i <= n; i++ {
+			cor.YieldRef(i)
+		}
+	})
+	return cor
+}
+
+func TestCorToSeq(t *testing.T) {
+	cleaned := make(chan bool, 1)
+
+	// Iterate all
+	var actual []int
+	seq := newCorCounter(3, cleaned).ToSeq()
+	seq(func(value int) bool {
+		actual = append(actual, value)
+		return true
+	})
+	assert.Equal(t, []int{1, 2, 3}, actual)
+	assert.Equal(t, true, <-cleaned)
+
+	// Break early: the Cor is closed & the deferred calls run
+	actual = nil
+	cor := newCorCounter(100, cleaned)
+	cor.ToSeq()(func(value int) bool {
+		actual = append(actual, value)
+		return value < 2
+	})
+	assert.Equal(t, []int{1, 2}, actual)
+	assert.Equal(t, true, <-cleaned)
+	assert.Equal(t, true, cor.IsDone())
+	assert.Equal(t, ErrCorClosed, cor.Err())
+
+	// Errors stop the iteration
+	var failed *CorDef[int]
+	failed = CorNewGenerics[int](func() {
+		failed.YieldRef(1)
+		panic("boom")
+	})
+	actual = nil
+	failed.ToSeq()(func(value int) bool {
+		actual = append(actual, value)
+		return true
+	})
+	assert.Equal(t, []int{1}, actual)
+	assert.Equal(t, CorPanicError{Cause: "boom"}, failed.Err())
+}
+
+func TestCorToChannel(t *testing.T) {
+	cleaned := make(chan bool, 1)
+
+	var actual []int
+	for value := range newCorCounter(3, cleaned).ToChannel(context.Background()) {
+		actual = append(actual, value)
+	}
+	assert.Equal(t, []int{1, 2, 3}, actual)
+	assert.Equal(t, true, <-cleaned)
+
+	// Cancel: the Cor is closed & the channel is closed
+	ctx, cancel := context.WithCancel(context.Background())
+	cor := newCorCounter(100, cleaned)
+	ch := cor.ToChannel(ctx)
+	assert.Equal(t, 1, <-ch)
+	cancel()
+	for range ch {
+	}
+	assert.Equal(t, true, <-cleaned)
+	<-cor.Done()
+}
+
+func TestCorToStream(t *testing.T) {
+	cleaned := make(chan bool, 1)
+
+	stream := CorToStreamGenerics(newCorCounter(3, cleaned))
+	assert.Equal(t, []int{1, 2, 3}, stream.ToArray())
+	assert.Equal(t, true, <-cleaned)
+}
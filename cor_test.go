@@ -1,9 +1,11 @@
 package fpgo
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -123,3 +125,165 @@ func TestCorDoNotation(t *testing.T) {
 
 	assert.Equal(t, expectedInt, (actual))
 }
+
+func TestCorErrorPropagation(t *testing.T) {
+	errExpected := fmt.Errorf("expected")
+
+	// Panics of the target: returned by YieldFromWithErr
+	var c1 *CorDef[interface{}]
+	c1 = Cor.New(func() {
+		c1.YieldRef(nil)
+		panic("boom")
+	})
+	c1.Start()
+	caller := Cor.New(func() {})
+	_, err := caller.YieldFromWithErr(c1, 1)
+	assert.Equal(t, nil, err)
+	_, err = caller.YieldFromWithErr(c1, 1)
+	assert.Equal(t, CorPanicError{Cause: "boom"}, err)
+	assert.Equal(t, err, c1.Err())
+
+	// Panics of the target: re-raised by YieldFrom & DoNotation
+	c2 := Cor.NewAndStart(func() {
+		panic("boom")
+	})
+	assert.PanicsWithValue(t, "boom", func() {
+		Cor.DoNotation(func(self *CorDef[interface{}]) interface{} {
+			return self.YieldFrom(c2, nil)
+		})
+	})
+
+	// Returned errors
+	c3 := Cor.NewWithContext(context.Background(), func() error {
+		return errExpected
+	})
+	c3.Start()
+	_, err = Cor.DoNotationWithContext(context.Background(), func(self *CorDef[interface{}]) (interface{}, error) {
+		return self.YieldFrom(c3, nil), nil
+	})
+	assert.Equal(t, errExpected, err)
+	c3 = Cor.NewWithContext(context.Background(), func() error {
+		return errExpected
+	})
+	c3.Start()
+	assert.PanicsWithValue(t, errExpected, func() {
+		Cor.DoNotation(func(self *CorDef[interface{}]) interface{} {
+			return self.YieldFrom(c3, nil)
+		})
+	})
+
+	// Done without yielding
+	c4 := Cor.NewAndStart(func() {})
+	<-c4.Done()
+	_, err = caller.YieldFromWithErr(c4, nil)
+	assert.Equal(t, ErrCorDone, err)
+	assert.Equal(t, nil, c4.Err())
+}
+
+func TestCorCancel(t *testing.T) {
+	// Cancel the target: the effect unwinds from YieldRef & the deferred calls run
+	cleaned := make(chan bool, 1)
+	var c1 *CorDef[interface{}]
+	c1 = Cor.New(func() {
+		defer func() {
+			cleaned <- true
+		}()
+		c1.YieldRef(nil)
+		cleaned <- false
+	})
+	c1.Start()
+	c1.Cancel()
+	assert.Equal(t, true, <-cleaned)
+	<-c1.Done()
+	assert.Equal(t, context.Canceled, c1.Err())
+
+	// Cancel the caller by the context: YieldFrom waiting for a silent target is unblocked
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var silent *CorDef[interface{}]
+	silent = Cor.New(func() {
+		<-silent.GetContext().Done()
+	})
+	silent.Start()
+	_, err := Cor.DoNotationWithContext(ctx, func(self *CorDef[interface{}]) (interface{}, error) {
+		return self.YieldFrom(silent, nil), nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	silent.Cancel()
+	<-silent.Done()
+	assert.Equal(t, nil, silent.Err())
+}
+
+func TestCorThrowClose(t *testing.T) {
+	errExpected := fmt.Errorf("expected")
+	caller := Cor.New(func() {})
+
+	// Throw: handled by YieldRefWithErr
+	var c1 *CorDef[interface{}]
+	c1 = Cor.New(func() {
+		_, err := c1.YieldRefWithErr(nil)
+		c1.YieldRef(err)
+	})
+	c1.Start()
+	c1.Throw(errExpected)
+	actual, err := caller.YieldFromWithErr(c1, nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, errExpected, actual)
+
+	// Throw: unwinding YieldRef & the deferred calls run
+	cleaned := make(chan bool, 1)
+	var c2 *CorDef[interface{}]
+	c2 = Cor.New(func() {
+		defer func() {
+			cleaned <- true
+		}()
+		c2.YieldRef(nil)
+		cleaned <- false
+	})
+	c2.Start()
+	c2.Throw(errExpected)
+	assert.Equal(t, true, <-cleaned)
+	<-c2.Done()
+	assert.Equal(t, errExpected, c2.Err())
+	_, err = caller.YieldFromWithErr(c2, nil)
+	assert.Equal(t, errExpected, err)
+
+	// Close: the deferred calls run before Close returns
+	var c3 *CorDef[interface{}]
+	c3 = Cor.New(func() {
+		defer func() {
+			cleaned <- true
+		}()
+		for {
+			c3.YieldRef(nil)
+		}
+	})
+	c3.Start()
+	caller.YieldFrom(c3, nil)
+	assert.Equal(t, nil, c3.Close())
+	assert.Equal(t, true, <-cleaned)
+	assert.Equal(t, true, c3.IsDone())
+	assert.Equal(t, ErrCorClosed, c3.Err())
+	_, err = caller.YieldFromWithErr(c3, nil)
+	assert.Equal(t, ErrCorDone, err)
+
+	// Close: errors of the cleanup are returned
+	var c4 *CorDef[interface{}]
+	c4 = Cor.New(func() {
+		defer func() {
+			panic("cleanup")
+		}()
+		c4.YieldRef(nil)
+	})
+	c4.Start()
+	assert.Equal(t, CorPanicError{Cause: "cleanup"}, c4.Close())
+
+	// Close: not started
+	c5 := Cor.New(func() {
+		cleaned <- false
+	})
+	assert.Equal(t, nil, c5.Close())
+	c5.Start()
+	assert.Equal(t, true, c5.IsDone())
+	assert.Equal(t, 0, len(cleaned))
+}
@@ -1791,6 +1791,12 @@ type RegexPatternDef struct {
 	effect  fnObj
 }
 
+// TypePatternDef Pattern which matching when the type of the given value is assignable to the given one
+type TypePatternDef struct {
+	valueType reflect.Type
+	effect    fnObj
+}
+
 // OtherwisePatternDef Pattern which matching when the others didn't match(finally)
 type OtherwisePatternDef struct {
 	effect fnObj
@@ -1798,10 +1804,21 @@ type OtherwisePatternDef struct {
 
 // MatchFor Check does the given value match anyone of the Pattern list of PatternMatching
 func (patternMatchingSelf PatternMatching) MatchFor(inValue interface{}) interface{} {
+	result, ok := patternMatchingSelf.TryMatchFor(inValue)
+	if !ok {
+		panic(fmt.Sprintf("Cannot match %v", inValue))
+	}
+
+	return result
+}
+
+// TryMatchFor Check does the given value match anyone of the Pattern list of PatternMatching (ok is false if none matches)
+func (patternMatchingSelf PatternMatching) TryMatchFor(inValue interface{}) (interface{}, bool) {
 	for _, pattern := range patternMatchingSelf.patterns {
 		value := inValue
 		maybe := Maybe.Just(inValue)
-		if maybe.IsKind(reflect.Ptr) {
+		// Type patterns match the types as they are (e.g. *T implementing interfaces), no dereferences
+		if _, isTypePattern := pattern.(TypePatternDef); !isTypePattern && maybe.IsKind(reflect.Ptr) {
 			ptr := maybe.ToPtr()
 			if reflect.TypeOf(*ptr).Kind() == (reflect.TypeOf(CompData{}).Kind()) {
 				value = *ptr
@@ -1809,11 +1826,11 @@ func (patternMatchingSelf PatternMatching) MatchFor(inValue interface{}) interfa
 		}
 
 		if pattern.Matches(value) {
-			return pattern.Apply(value)
+			return pattern.Apply(value), true
 		}
 	}
 
-	panic(fmt.Sprintf("Cannot match %v", inValue))
+	return nil, false
 }
 
 // Matches Match the given value by the pattern
@@ -1827,8 +1844,8 @@ func (patternSelf KindPatternDef) Matches(value interface{}) bool {
 
 // Matches Match the given value by the pattern
 func (patternSelf CompTypePatternDef) Matches(value interface{}) bool {
-	if Maybe.Just(value).IsPresent() && reflect.TypeOf(value).Kind() == reflect.TypeOf(CompData{}).Kind() {
-		return MatchCompType(patternSelf.compType, (value).(CompData))
+	if compData, ok := value.(CompData); ok {
+		return MatchCompType(patternSelf.compType, compData)
 	}
 
 	return patternSelf.compType.Matches(value)
@@ -1853,6 +1870,15 @@ func (patternSelf RegexPatternDef) Matches(value interface{}) bool {
 	return false
 }
 
+// Matches Match the given value by the pattern
+func (patternSelf TypePatternDef) Matches(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+
+	return reflect.TypeOf(value).AssignableTo(patternSelf.valueType)
+}
+
 // Matches Match the given value by the pattern
 func (patternSelf OtherwisePatternDef) Matches(value interface{}) bool {
 	return true
@@ -1878,6 +1904,11 @@ func (patternSelf RegexPatternDef) Apply(value interface{}) interface{} {
 	return patternSelf.effect(value)
 }
 
+// Apply Evaluate the result by its given effect function
+func (patternSelf TypePatternDef) Apply(value interface{}) interface{} {
+	return patternSelf.effect(value)
+}
+
 // Apply Evaluate the result by its given effect function
 func (patternSelf OtherwisePatternDef) Apply(value interface{}) interface{} {
 	return patternSelf.effect(value)
@@ -1908,6 +1939,11 @@ func InCaseOfRegex(pattern string, effect fnObj) Pattern {
 	return RegexPatternDef{pattern: pattern, effect: effect}
 }
 
+// InCaseOfTypeGenerics In case of its type is assignable to T (e.g. the implementations of the interface T, pointers are not dereferenced)
+func InCaseOfTypeGenerics[T any](effect fnObj) Pattern {
+	return TypePatternDef{valueType: reflect.TypeOf((*T)(nil)).Elem(), effect: effect}
+}
+
 // Otherwise In case of the other patterns didn't match it
 func Otherwise(effect fnObj) Pattern {
 	return OtherwisePatternDef{effect: effect}
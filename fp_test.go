@@ -342,4 +342,16 @@ func TestPatternMatching(t *testing.T) {
 	assert.Equal(t, "Matched: ccc", Either("ccc", patterns...))
 	assert.Equal(t, "SumType 1 1", Either(NewCompData(myType, ("1"), ("1")), patterns...))
 	assert.Equal(t, "got this object: TEST", Either("TEST", patterns...))
+
+	// Pointers to structs are matched by the structs
+	type point struct{ X, Y int }
+	pm = DefPattern(
+		InCaseOfSumType(myType, func(x interface{}) interface{} {
+			return "SumType"
+		}),
+		InCaseOfKind(reflect.Struct, func(x interface{}) interface{} {
+			return (fmt.Sprintf("Struct: %v", x))
+		}),
+	)
+	assert.Equal(t, "Struct: {1 2}", pm.MatchFor(&point{X: 1, Y: 2}))
 }
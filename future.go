@@ -0,0 +1,345 @@
+package fpgo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrFutureTimeout = fmt.Errorf("ErrFutureTimeout")
+	ErrFutureEmpty   = fmt.Errorf("ErrFutureEmpty")
+)
+
+// FuturePanicError The panic of the effect/callback of a Future
+type FuturePanicError struct {
+	Cause interface{}
+}
+
+// Error Show the panic cause
+func (errSelf FuturePanicError) Error() string {
+	return fmt.Sprintf("FuturePanicError: %v", errSelf.Cause)
+}
+
+// FutureAggregateError The errors of all Futures of FutureAnyGenerics (in the order of the Futures)
+type FutureAggregateError struct {
+	Errors []error
+}
+
+// Error Show the errors
+func (errSelf FutureAggregateError) Error() string {
+	return fmt.Sprintf("FutureAggregateError: %v", errSelf.Errors)
+}
+
+// FutureDef Future/Promise inspired by Scala/Ecmascript, an eager & composable async result
+//
+// It's completed once(by the effect, or by Resolve()/Reject() of a Promise), and can be awaited by many parties.
+type FutureDef[T any] struct {
+	done         chan struct{}
+	completeOnce sync.Once
+	result       T
+	err          error
+}
+
+// New New a Future running the effect in a new goroutine
+func (futureSelf *FutureDef[T]) New(effect func() (interface{}, error)) *FutureDef[interface{}] {
+	return FutureNewGenerics(effect)
+}
+
+// Just New a Future completed by the value
+func (futureSelf *FutureDef[T]) Just(in interface{}) *FutureDef[interface{}] {
+	return FutureJustGenerics(in)
+}
+
+// FutureNewGenerics New a Future running the effect in a new goroutine
+func FutureNewGenerics[T any](effect func() (T, error)) *FutureDef[T] {
+	return FutureNewOnGenerics(nil, effect)
+}
+
+// FutureNewOnGenerics New a Future running the effect on the Handler (nil: in a new goroutine)
+//
+// Panics of the effect fail the Future by FuturePanicError.
+func FutureNewOnGenerics[T any](h *HandlerDef, effect func() (T, error)) *FutureDef[T] {
+	future := FuturePromiseGenerics[T]()
+	run := func() {
+		var result T
+		err := runFutureEffect(func() error {
+			var err error
+			result, err = effect()
+			return err
+		})
+		future.complete(result, err)
+	}
+
+	if h != nil {
+		h.Post(run)
+	} else {
+		go run()
+	}
+	return future
+}
+
+// FutureJustGenerics New a Future completed by the value
+func FutureJustGenerics[T any](in T) *FutureDef[T] {
+	future := FuturePromiseGenerics[T]()
+	future.Resolve(in)
+	return future
+}
+
+// FuturePromiseGenerics New a Future(Promise) completed by Resolve()/Reject()
+func FuturePromiseGenerics[T any]() *FutureDef[T] {
+	return &FutureDef[T]{done: make(chan struct{})}
+}
+
+// Resolve Complete the Future by the value (false if it's completed already)
+func (futureSelf *FutureDef[T]) Resolve(in T) bool {
+	return futureSelf.complete(in, nil)
+}
+
+// Reject Fail the Future by the error (false if it's completed already)
+func (futureSelf *FutureDef[T]) Reject(err error) bool {
+	var result T
+	return futureSelf.complete(result, err)
+}
+
+// Await Wait for the result of the Future until ctx is done
+func (futureSelf *FutureDef[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-futureSelf.done:
+		return futureSelf.result, futureSelf.err
+	case <-ctx.Done():
+		var result T
+		return result, ctx.Err()
+	}
+}
+
+// Done Get the channel closed when the Future is completed
+func (futureSelf *FutureDef[T]) Done() <-chan struct{} {
+	return futureSelf.done
+}
+
+// IsDone Is the Future completed
+func (futureSelf *FutureDef[T]) IsDone() bool {
+	select {
+	case <-futureSelf.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Then Chain the Future returned by fn after this one succeeded
+func (futureSelf *FutureDef[T]) Then(fn func(T) *FutureDef[T]) *FutureDef[T] {
+	return FutureThenGenerics(futureSelf, fn)
+}
+
+// Map Map the result after this Future succeeded
+func (futureSelf *FutureDef[T]) Map(fn func(T) T) *FutureDef[T] {
+	return FutureMapGenerics(futureSelf, fn)
+}
+
+// Recover Recover from the error of this Future by fn (fn could return an error to keep it failed)
+func (futureSelf *FutureDef[T]) Recover(fn func(error) (T, error)) *FutureDef[T] {
+	next := FuturePromiseGenerics[T]()
+	go func() {
+		<-futureSelf.done
+		if futureSelf.err == nil {
+			next.complete(futureSelf.result, nil)
+			return
+		}
+
+		var result T
+		err := runFutureEffect(func() error {
+			var err error
+			result, err = fn(futureSelf.err)
+			return err
+		})
+		next.complete(result, err)
+	}()
+
+	return next
+}
+
+// WithTimeout Fail by ErrFutureTimeout if this Future isn't completed within the timeout
+func (futureSelf *FutureDef[T]) WithTimeout(timeout time.Duration) *FutureDef[T] {
+	next := FuturePromiseGenerics[T]()
+	go func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-futureSelf.done:
+			next.complete(futureSelf.result, futureSelf.err)
+		case <-timer.C:
+			next.Reject(ErrFutureTimeout)
+		}
+	}()
+
+	return next
+}
+
+// FutureThenGenerics Chain the Future returned by fn after the Future succeeded (nil: completed by the zero value)
+func FutureThenGenerics[T any, R any](future *FutureDef[T], fn func(T) *FutureDef[R]) *FutureDef[R] {
+	next := FuturePromiseGenerics[R]()
+	go func() {
+		<-future.done
+		if future.err != nil {
+			next.Reject(future.err)
+			return
+		}
+
+		var chained *FutureDef[R]
+		err := runFutureEffect(func() error {
+			chained = fn(future.result)
+			return nil
+		})
+		if err != nil {
+			next.Reject(err)
+			return
+		}
+		if chained == nil {
+			var result R
+			next.Resolve(result)
+			return
+		}
+		next.complete(chained.Await(context.Background()))
+	}()
+
+	return next
+}
+
+// FutureMapGenerics Map the result after the Future succeeded
+func FutureMapGenerics[T any, R any](future *FutureDef[T], fn func(T) R) *FutureDef[R] {
+	return FutureThenGenerics(future, func(in T) *FutureDef[R] {
+		return FutureJustGenerics(fn(in))
+	})
+}
+
+// FutureAllGenerics Collect results of all Futures (in the order of the Futures), failed by the first error
+func FutureAllGenerics[T any](futures ...*FutureDef[T]) *FutureDef[[]T] {
+	all := FuturePromiseGenerics[[]T]()
+	results := make([]T, len(futures))
+	if len(futures) == 0 {
+		all.Resolve(results)
+		return all
+	}
+
+	remaining := int32(len(futures))
+	for i, future := range futures {
+		i, future := i, future
+		go func() {
+			<-future.done
+			if future.err != nil {
+				all.Reject(future.err)
+				return
+			}
+
+			results[i] = future.result
+			if atomic.AddInt32(&remaining, -1) == 0 {
+				all.Resolve(results)
+			}
+		}()
+	}
+
+	return all
+}
+
+// FutureAnyGenerics Get the result of the first succeeded Future, failed by FutureAggregateError if all failed
+func FutureAnyGenerics[T any](futures ...*FutureDef[T]) *FutureDef[T] {
+	first := FuturePromiseGenerics[T]()
+	if len(futures) == 0 {
+		first.Reject(ErrFutureEmpty)
+		return first
+	}
+
+	errs := make([]error, len(futures))
+	remaining := int32(len(futures))
+	for i, future := range futures {
+		i, future := i, future
+		go func() {
+			<-future.done
+			if future.err == nil {
+				first.Resolve(future.result)
+				return
+			}
+
+			errs[i] = future.err
+			if atomic.AddInt32(&remaining, -1) == 0 {
+				first.Reject(FutureAggregateError{Errors: errs})
+			}
+		}()
+	}
+
+	return first
+}
+
+// FutureRaceGenerics Get the result/error of the first completed Future
+func FutureRaceGenerics[T any](futures ...*FutureDef[T]) *FutureDef[T] {
+	first := FuturePromiseGenerics[T]()
+	if len(futures) == 0 {
+		first.Reject(ErrFutureEmpty)
+		return first
+	}
+
+	for _, future := range futures {
+		future := future
+		go func() {
+			<-future.done
+			first.complete(future.result, future.err)
+		}()
+	}
+
+	return first
+}
+
+// FutureFromMonadIOGenerics New a Future by evaluating the MonadIO (on its ObserveOn Handler if any)
+//
+// Panics of the effect fail the Future by FuturePanicError.
+func FutureFromMonadIOGenerics[T any](target *MonadIODef[T]) *FutureDef[T] {
+	return FutureNewOnGenerics(target.obOn, func() (T, error) {
+		return target.doEffect(), nil
+	})
+}
+
+// FutureDoNotationGenerics New a Future by the Do Notation of Cor (errors of it fail the Future)
+func FutureDoNotationGenerics[T any](ctx context.Context, effect func(*CorDef[T]) (T, error)) *FutureDef[T] {
+	return FutureNewGenerics(func() (T, error) {
+		var cor CorDef[T]
+		return cor.DoNotationWithContext(ctx, effect)
+	})
+}
+
+// FutureAskGenerics New a Future by Asking the target until ctx is done
+func FutureAskGenerics[T any, R any](ctx context.Context, target ActorHandle[interface{}], message T) *FutureDef[R] {
+	return FutureNewGenerics(func() (R, error) {
+		return AskNewGenerics[T, R](message).AskOnceWithContext(ctx, target)
+	})
+}
+
+func (futureSelf *FutureDef[T]) complete(result T, err error) bool {
+	completed := false
+	futureSelf.completeOnce.Do(func() {
+		futureSelf.result = result
+		futureSelf.err = err
+		completed = true
+		close(futureSelf.done)
+	})
+
+	return completed
+}
+
+// runFutureEffect Run the effect of a Future, returning its error or the panic(FuturePanicError)
+func runFutureEffect(effect func() error) (err error) {
+	defer func() {
+		if cause := recover(); cause != nil {
+			err = FuturePanicError{Cause: cause}
+		}
+	}()
+
+	return effect()
+}
+
+// Future Future utils instance
+var Future FutureDef[interface{}]
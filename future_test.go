@@ -0,0 +1,141 @@
+package fpgo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFutureCombinators(t *testing.T) {
+	ctx := context.Background()
+	errExpected := fmt.Errorf("expected")
+
+	// Then/Map
+	future := FutureNewGenerics(func() (int, error) {
+		return 1, nil
+	}).Then(func(in int) *FutureDef[int] {
+		return FutureJustGenerics(in + 1)
+	}).Map(func(in int) int {
+		return in * 10
+	})
+	result, err := future.Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 20, result)
+	text, err := FutureMapGenerics(future, strconv.Itoa).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "20", text)
+
+	// Errors & panics skip Then/Map, and Recover
+	failed := FutureNewGenerics(func() (int, error) {
+		return 0, errExpected
+	}).Map(func(in int) int {
+		return in + 1
+	})
+	_, err = failed.Await(ctx)
+	assert.Equal(t, errExpected, err)
+	result, err = failed.Recover(func(err error) (int, error) {
+		return -1, nil
+	}).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, -1, result)
+	_, err = FutureNewOnGenerics(Handler.GetDefault(), func() (int, error) {
+		panic("boom")
+	}).Await(ctx)
+	assert.Equal(t, FuturePanicError{Cause: "boom"}, err)
+
+	// All
+	results, err := FutureAllGenerics(FutureJustGenerics(1), future, FutureNewGenerics(func() (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 3, nil
+	})).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, []int{1, 20, 3}, results)
+	_, err = FutureAllGenerics(FutureJustGenerics(1), failed).Await(ctx)
+	assert.Equal(t, errExpected, err)
+	results, _ = FutureAllGenerics[int]().Await(ctx)
+	assert.Equal(t, []int{}, results)
+
+	// Any
+	never := FuturePromiseGenerics[int]()
+	result, err = FutureAnyGenerics(failed, never, FutureJustGenerics(2)).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 2, result)
+	_, err = FutureAnyGenerics(failed, failed).Await(ctx)
+	assert.Equal(t, FutureAggregateError{Errors: []error{errExpected, errExpected}}, err)
+	_, err = FutureAnyGenerics[int]().Await(ctx)
+	assert.Equal(t, ErrFutureEmpty, err)
+
+	// Race
+	_, err = FutureRaceGenerics(never, failed).Await(ctx)
+	assert.Equal(t, errExpected, err)
+
+	// Timeouts & Await(ctx)
+	_, err = never.WithTimeout(10 * time.Millisecond).Await(ctx)
+	assert.Equal(t, ErrFutureTimeout, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = never.Await(timeoutCtx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// Promise
+	assert.Equal(t, false, never.IsDone())
+	assert.Equal(t, true, never.Resolve(5))
+	assert.Equal(t, false, never.Reject(errExpected))
+	result, err = never.Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 5, result)
+}
+
+func TestFutureAdapters(t *testing.T) {
+	ctx := context.Background()
+
+	// MonadIO
+	h := Handler.New()
+	defer h.Close()
+	result, err := FutureFromMonadIOGenerics(MonadIOJustGenerics(1).ObserveOn(h)).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, result)
+	// Panics of the effect on the Handler fail the Future (the Handler keeps running)
+	_, err = FutureFromMonadIOGenerics(MonadIONewGenerics(func() int {
+		panic("boom")
+	}).ObserveOn(h)).Await(ctx)
+	assert.Equal(t, FuturePanicError{Cause: "boom"}, err)
+	result, err = FutureFromMonadIOGenerics(MonadIOJustGenerics(1).ObserveOn(h)).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 1, result)
+
+	// Do Notation
+	var c1 *CorDef[int]
+	c1 = CorNewGenerics[int](func() {
+		c1.YieldRef(2)
+	})
+	c1.Start()
+	result, err = FutureDoNotationGenerics(ctx, func(self *CorDef[int]) (int, error) {
+		return self.YieldFrom(c1, 0) + self.YieldFromIO(MonadIOJustGenerics(1)), nil
+	}).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 3, result)
+
+	// Ask
+	actor := Actor.New(func(self *ActorDef[interface{}], input interface{}) {
+		ask := input.(*AskDef[interface{}, interface{}])
+		ask.Reply(ask.Message.(int) * 2)
+	})
+	defer actor.Close()
+	response, err := FutureAskGenerics[interface{}, interface{}](ctx, actor, 21).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, 42, response)
+
+	// Utils instance
+	response, err = Future.New(func() (interface{}, error) {
+		return "ok", nil
+	}).Await(ctx)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ok", response)
+	response, _ = Future.Just(1).Await(ctx)
+	assert.Equal(t, 1, response)
+}
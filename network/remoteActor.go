@@ -0,0 +1,697 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"path"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	fpgo "github.com/TeaEntityLab/fpGo"
+)
+
+// Remote Actors over TCP
+
+var (
+	ErrRemoteActorNotFound = fmt.Errorf("ErrRemoteActorNotFound")
+	ErrRemoteNodeClosed    = fmt.Errorf("ErrRemoteNodeClosed")
+	ErrRemoteUnknownType   = fmt.Errorf("ErrRemoteUnknownType")
+)
+
+const (
+	// RemoteDialTimeout Timeout of dialing remote nodes
+	RemoteDialTimeout = 10 * time.Second
+	// RemoteDeliveryCapacity Max queued messages of a recipient per connection (overflowed ones are reported to the DeadLetters/Ask callers)
+	RemoteDeliveryCapacity = 1024
+	// RemoteDeliveryIdleTimeout The queue of a recipient per connection is released after it has been idle for the timeout
+	RemoteDeliveryIdleTimeout = 10 * time.Second
+)
+
+// RemoteConnectionFailure Notification of a failed connection to a remote node
+type RemoteConnectionFailure struct {
+	Address string
+	Err     error
+}
+
+// RemoteNodeDef A node exposing Actors of the ActorSystem to remote nodes, and referring to remote Actors
+//
+// Message types should be registered on both sides by RemoteRegisterGenerics (string/bool/int/int64/float64 by default).
+type RemoteNodeDef struct {
+	system *fpgo.ActorSystemDef
+	codec  RemoteCodec
+
+	isClosed fpgo.AtomBool
+	listener net.Listener
+	address  string
+
+	types      map[string]reflect.Type
+	typeNames  map[reflect.Type]string
+	refs       map[string]*RemoteActorRefDef
+	outbound   map[string]*remoteConnection
+	inbound    map[*remoteConnection]bool
+	nodeM      sync.Mutex
+	connectMs  map[string]*sync.Mutex
+	asks       map[uint64]*remoteAsk
+	asksM      sync.Mutex
+	askCounter uint64
+
+	failures *fpgo.PublisherDef[RemoteConnectionFailure]
+}
+
+type remoteAsk struct {
+	connection *remoteConnection
+	ch         chan *fpgo.AskResponseWithError[interface{}]
+}
+
+type remoteConnection struct {
+	node      *RemoteNodeDef
+	address   string
+	conn      net.Conn
+	writeM    sync.Mutex
+	closeOnce sync.Once
+
+	// deliverers Deliver received messages in order per recipient, off the reader goroutine (nil: closed)
+	deliverers  map[string]*fpgo.ActorDef[*remoteDelivery]
+	deliverersM sync.Mutex
+}
+
+type remoteDelivery struct {
+	frame     *remoteFrame
+	recipient fpgo.ActorRef
+	message   interface{}
+	sender    fpgo.ActorRef
+}
+
+// NewRemoteNode New a RemoteNode of the ActorSystem with the RemoteCodec (JSONRemoteCodec if nil)
+func NewRemoteNode(system *fpgo.ActorSystemDef, codec RemoteCodec) *RemoteNodeDef {
+	if codec == nil {
+		codec = JSONRemoteCodec{}
+	}
+	newOne := &RemoteNodeDef{
+		system:    system,
+		codec:     codec,
+		types:     map[string]reflect.Type{},
+		typeNames: map[reflect.Type]string{},
+		refs:      map[string]*RemoteActorRefDef{},
+		outbound:  map[string]*remoteConnection{},
+		connectMs: map[string]*sync.Mutex{},
+		inbound:   map[*remoteConnection]bool{},
+		asks:      map[uint64]*remoteAsk{},
+		failures:  fpgo.PublisherNewGenerics[RemoteConnectionFailure](),
+	}
+	RemoteRegisterGenerics[string](newOne)
+	RemoteRegisterGenerics[bool](newOne)
+	RemoteRegisterGenerics[int](newOne)
+	RemoteRegisterGenerics[int64](newOne)
+	RemoteRegisterGenerics[float64](newOne)
+
+	return newOne
+}
+
+// RemoteRegisterGenerics Register T as a message type of remote Actors (by its Go type name)
+func RemoteRegisterGenerics[T any](node *RemoteNodeDef) {
+	messageType := reflect.TypeOf((*T)(nil)).Elem()
+
+	node.nodeM.Lock()
+	defer node.nodeM.Unlock()
+	node.types[messageType.String()] = messageType
+	node.typeNames[messageType] = messageType.String()
+}
+
+// Listen Accept connections of remote nodes on the TCP address (e.g. 127.0.0.1:0)
+func (nodeSelf *RemoteNodeDef) Listen(address string) error {
+	if nodeSelf.isClosed.Get() {
+		return ErrRemoteNodeClosed
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	nodeSelf.nodeM.Lock()
+	nodeSelf.listener = listener
+	nodeSelf.address = listener.Addr().String()
+	nodeSelf.nodeM.Unlock()
+
+	go nodeSelf.accept(listener)
+	return nil
+}
+
+// GetAddress Get the listening address ("" if it's not listening)
+func (nodeSelf *RemoteNodeDef) GetAddress() string {
+	nodeSelf.nodeM.Lock()
+	defer nodeSelf.nodeM.Unlock()
+	return nodeSelf.address
+}
+
+// ActorOf Refer to the Actor of the path on the remote node of the address
+func (nodeSelf *RemoteNodeDef) ActorOf(address string, actorPath string) *RemoteActorRefDef {
+	key := address + actorPath
+
+	nodeSelf.nodeM.Lock()
+	defer nodeSelf.nodeM.Unlock()
+	ref := nodeSelf.refs[key]
+	if ref == nil {
+		ref = &RemoteActorRefDef{
+			node:        nodeSelf,
+			address:     address,
+			path:        actorPath,
+			termination: fpgo.PublisherNewGenerics[fpgo.ActorTerminated](),
+		}
+		nodeSelf.refs[key] = ref
+	}
+	return ref
+}
+
+// SubscribeConnectionFailure Subscribe failures of connections to remote nodes
+func (nodeSelf *RemoteNodeDef) SubscribeConnectionFailure(fn func(RemoteConnectionFailure)) *fpgo.Subscription[RemoteConnectionFailure] {
+	return nodeSelf.failures.Subscribe(fpgo.Subscription[RemoteConnectionFailure]{OnNext: fn})
+}
+
+// UnsubscribeConnectionFailure Unsubscribe failures of connections by the Subscription
+func (nodeSelf *RemoteNodeDef) UnsubscribeConnectionFailure(s *fpgo.Subscription[RemoteConnectionFailure]) {
+	nodeSelf.failures.Unsubscribe(s)
+}
+
+// Close Stop listening & close all connections
+func (nodeSelf *RemoteNodeDef) Close() {
+	nodeSelf.isClosed.Set(true)
+
+	nodeSelf.nodeM.Lock()
+	listener := nodeSelf.listener
+	var connections []*remoteConnection
+	for _, connection := range nodeSelf.outbound {
+		connections = append(connections, connection)
+	}
+	for connection := range nodeSelf.inbound {
+		connections = append(connections, connection)
+	}
+	nodeSelf.nodeM.Unlock()
+
+	if listener != nil {
+		listener.Close()
+	}
+	for _, connection := range connections {
+		connection.close(ErrRemoteNodeClosed)
+	}
+}
+
+func (nodeSelf *RemoteNodeDef) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		connection := nodeSelf.newConnection("", conn)
+		nodeSelf.nodeM.Lock()
+		nodeSelf.inbound[connection] = true
+		nodeSelf.nodeM.Unlock()
+		if nodeSelf.isClosed.Get() {
+			connection.close(ErrRemoteNodeClosed)
+			return
+		}
+		go connection.read()
+	}
+}
+
+// connect Get the connection to the address, dial it if it's not connected
+func (nodeSelf *RemoteNodeDef) connect(address string) (*remoteConnection, error) {
+	if nodeSelf.isClosed.Get() {
+		return nil, ErrRemoteNodeClosed
+	}
+
+	// Dial once for concurrent senders of the address
+	nodeSelf.nodeM.Lock()
+	connectM := nodeSelf.connectMs[address]
+	if connectM == nil {
+		connectM = &sync.Mutex{}
+		nodeSelf.connectMs[address] = connectM
+	}
+	nodeSelf.nodeM.Unlock()
+	connectM.Lock()
+	defer connectM.Unlock()
+	nodeSelf.nodeM.Lock()
+	connection := nodeSelf.outbound[address]
+	nodeSelf.nodeM.Unlock()
+	if connection != nil {
+		return connection, nil
+	}
+
+	conn, err := net.DialTimeout("tcp", address, RemoteDialTimeout)
+	if err != nil {
+		go nodeSelf.notifyFailure(address, err)
+		return nil, err
+	}
+	connection = nodeSelf.newConnection(address, conn)
+	nodeSelf.nodeM.Lock()
+	nodeSelf.outbound[address] = connection
+	nodeSelf.nodeM.Unlock()
+	go connection.read()
+
+	return connection, nil
+}
+
+func (nodeSelf *RemoteNodeDef) send(address string, frame *remoteFrame, message interface{}) error {
+	err := nodeSelf.encodeMessage(frame, message)
+	if err != nil {
+		return err
+	}
+	connection, err := nodeSelf.connect(address)
+	if err != nil {
+		return err
+	}
+
+	return connection.write(frame)
+}
+
+func (nodeSelf *RemoteNodeDef) ask(ctx context.Context, ref *RemoteActorRefDef, message interface{}) (interface{}, error) {
+	frame := &remoteFrame{
+		Kind:          remoteFrameAsk,
+		Recipient:     ref.path,
+		CorrelationID: atomic.AddUint64(&nodeSelf.askCounter, 1),
+	}
+	err := nodeSelf.encodeMessage(frame, message)
+	if err != nil {
+		return nil, err
+	}
+	connection, err := nodeSelf.connect(ref.address)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := &remoteAsk{connection: connection, ch: make(chan *fpgo.AskResponseWithError[interface{}], 1)}
+	nodeSelf.asksM.Lock()
+	nodeSelf.asks[frame.CorrelationID] = pending
+	nodeSelf.asksM.Unlock()
+	defer func() {
+		nodeSelf.asksM.Lock()
+		delete(nodeSelf.asks, frame.CorrelationID)
+		nodeSelf.asksM.Unlock()
+	}()
+
+	err = connection.write(frame)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case response := <-pending.ch:
+		return response.Response, response.Err
+	case <-ctx.Done():
+		err := ctx.Err()
+		if err == context.DeadlineExceeded {
+			err = fpgo.ErrActorAskTimeout
+		}
+		return nil, err
+	}
+}
+
+func (nodeSelf *RemoteNodeDef) handleFrame(connection *remoteConnection, frame *remoteFrame) {
+	switch frame.Kind {
+	case remoteFrameReply, remoteFrameReplyError:
+		nodeSelf.asksM.Lock()
+		pending := nodeSelf.asks[frame.CorrelationID]
+		nodeSelf.asksM.Unlock()
+		if pending == nil {
+			// The Ask is done (e.g. timed out)
+			return
+		}
+
+		response := &fpgo.AskResponseWithError[interface{}]{}
+		if frame.Kind == remoteFrameReplyError {
+			response.Err = fmt.Errorf("%s", frame.Error)
+		} else {
+			response.Response, response.Err = nodeSelf.decodeMessage(frame)
+		}
+		select {
+		case pending.ch <- response:
+		default:
+			// Replied already
+		}
+	default:
+		err := nodeSelf.deliver(connection, frame)
+		if err != nil {
+			connection.replyError(frame, err)
+		}
+	}
+}
+func (nodeSelf *RemoteNodeDef) deliver(connection *remoteConnection, frame *remoteFrame) error {
+	message, err := nodeSelf.decodeMessage(frame)
+	if err != nil {
+		return err
+	}
+
+	delivery := &remoteDelivery{frame: frame, message: message}
+	if frame.Kind == remoteFrameAsk {
+		delivery.sender = &remoteReplyRef{connection: connection, correlationID: frame.CorrelationID}
+	} else if frame.SenderAddress != "" {
+		delivery.sender = nodeSelf.ActorOf(frame.SenderAddress, frame.Sender)
+	}
+	recipient, ok := nodeSelf.system.Lookup(frame.Recipient)
+	if !ok {
+		err = ErrRemoteActorNotFound
+	} else {
+		delivery.recipient = recipient
+		// Blocking Mailboxes of recipients won't stall the reader goroutine & the others
+		err = connection.deliver(frame.Recipient, delivery)
+	}
+	if err != nil {
+		nodeSelf.system.GetDeadLetters().Publish(fpgo.ActorDeadLetter{
+			Message: message,
+			Sender:  delivery.sender,
+			Reason:  err,
+		})
+	}
+	return err
+}
+
+func (nodeSelf *RemoteNodeDef) encodeMessage(frame *remoteFrame, message interface{}) error {
+	nodeSelf.nodeM.Lock()
+	typeName, ok := nodeSelf.typeNames[reflect.TypeOf(message)]
+	nodeSelf.nodeM.Unlock()
+	if !ok {
+		return ErrRemoteUnknownType
+	}
+
+	payload, err := nodeSelf.codec.Encode(message)
+	if err != nil {
+		return err
+	}
+	frame.Type = typeName
+	frame.Payload = payload
+	return nil
+}
+func (nodeSelf *RemoteNodeDef) decodeMessage(frame *remoteFrame) (interface{}, error) {
+	nodeSelf.nodeM.Lock()
+	messageType, ok := nodeSelf.types[frame.Type]
+	nodeSelf.nodeM.Unlock()
+	if !ok {
+		return nil, ErrRemoteUnknownType
+	}
+
+	message := reflect.New(messageType)
+	err := nodeSelf.codec.Decode(frame.Payload, message.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return message.Elem().Interface(), nil
+}
+
+// connectionFailed Clean up the failed connection, and notify watchers of the remote node
+func (nodeSelf *RemoteNodeDef) connectionFailed(connection *remoteConnection, err error) {
+	nodeSelf.nodeM.Lock()
+	delete(nodeSelf.inbound, connection)
+	isOutbound := connection.address != "" && nodeSelf.outbound[connection.address] == connection
+	if isOutbound {
+		delete(nodeSelf.outbound, connection.address)
+	}
+	nodeSelf.nodeM.Unlock()
+
+	// Fail the pending Asks of the connection
+	nodeSelf.asksM.Lock()
+	for _, pending := range nodeSelf.asks {
+		if pending.connection == connection {
+			select {
+			case pending.ch <- &fpgo.AskResponseWithError[interface{}]{Err: err}:
+			default:
+			}
+		}
+	}
+	nodeSelf.asksM.Unlock()
+
+	if isOutbound {
+		nodeSelf.notifyFailure(connection.address, err)
+	}
+}
+func (nodeSelf *RemoteNodeDef) notifyFailure(address string, err error) {
+	if nodeSelf.isClosed.Get() {
+		return
+	}
+
+	nodeSelf.failures.Publish(RemoteConnectionFailure{Address: address, Err: err})
+
+	var refs []*RemoteActorRefDef
+	nodeSelf.nodeM.Lock()
+	for _, ref := range nodeSelf.refs {
+		if ref.address == address {
+			refs = append(refs, ref)
+		}
+	}
+	nodeSelf.nodeM.Unlock()
+	for _, ref := range refs {
+		ref.terminated(err)
+	}
+}
+
+func (nodeSelf *RemoteNodeDef) newConnection(address string, conn net.Conn) *remoteConnection {
+	return &remoteConnection{
+		node:       nodeSelf,
+		address:    address,
+		conn:       conn,
+		deliverers: map[string]*fpgo.ActorDef[*remoteDelivery]{},
+	}
+}
+
+// deliver Queue the delivery to the recipient(path), returns error if the queue is full or the connection is closed
+func (connectionSelf *remoteConnection) deliver(path string, delivery *remoteDelivery) error {
+	connectionSelf.deliverersM.Lock()
+	defer connectionSelf.deliverersM.Unlock()
+	if connectionSelf.deliverers == nil {
+		return fpgo.ErrActorClosed
+	}
+
+	deliverer := connectionSelf.deliverers[path]
+	if deliverer == nil {
+		deliverer = fpgo.ActorNewWithOptionGenerics(func(self *fpgo.ActorDef[*remoteDelivery], delivery *remoteDelivery) {
+			if delivery == nil {
+				// Idle
+				connectionSelf.release(path, self)
+				return
+			}
+
+			err := delivery.recipient.TellForInterface(delivery.message, delivery.sender)
+			if err != nil {
+				connectionSelf.replyError(delivery.frame, err)
+			}
+		}, &fpgo.ActorOption[*remoteDelivery]{
+			Mailbox: &fpgo.MailboxOption[*remoteDelivery]{Capacity: RemoteDeliveryCapacity, Overflow: fpgo.MailboxFailFast},
+			PreStart: func(self *fpgo.ActorDef[*remoteDelivery]) {
+				self.SetReceiveTimeout(RemoteDeliveryIdleTimeout, nil)
+			},
+		})
+		connectionSelf.deliverers[path] = deliverer
+	}
+	return deliverer.TrySend(delivery)
+}
+
+// release Release the idle deliverer (unless deliveries are queued since then)
+func (connectionSelf *remoteConnection) release(path string, deliverer *fpgo.ActorDef[*remoteDelivery]) {
+	connectionSelf.deliverersM.Lock()
+	defer connectionSelf.deliverersM.Unlock()
+	if connectionSelf.deliverers[path] == deliverer && deliverer.GetMailboxSize() == 0 {
+		delete(connectionSelf.deliverers, path)
+		deliverer.Stop()
+	}
+}
+func (connectionSelf *remoteConnection) replyError(frame *remoteFrame, err error) {
+	if frame.Kind == remoteFrameAsk {
+		connectionSelf.write(&remoteFrame{Kind: remoteFrameReplyError, CorrelationID: frame.CorrelationID, Error: err.Error()})
+	}
+}
+
+func (connectionSelf *remoteConnection) write(frame *remoteFrame) error {
+	connectionSelf.writeM.Lock()
+	defer connectionSelf.writeM.Unlock()
+
+	err := writeRemoteFrame(connectionSelf.conn, connectionSelf.node.codec, frame)
+	if err != nil && err != ErrRemoteFrameTooLarge {
+		go connectionSelf.close(err)
+	}
+	return err
+}
+func (connectionSelf *remoteConnection) read() {
+	reader := bufio.NewReader(connectionSelf.conn)
+	for {
+		frame, err := readRemoteFrame(reader, connectionSelf.node.codec)
+		if err != nil {
+			connectionSelf.close(err)
+			return
+		}
+		connectionSelf.node.handleFrame(connectionSelf, frame)
+	}
+}
+func (connectionSelf *remoteConnection) close(err error) {
+	connectionSelf.closeOnce.Do(func() {
+		connectionSelf.conn.Close()
+		connectionSelf.deliverersM.Lock()
+		deliverers := connectionSelf.deliverers
+		connectionSelf.deliverers = nil
+		connectionSelf.deliverersM.Unlock()
+		for _, deliverer := range deliverers {
+			deliverer.Stop()
+		}
+		connectionSelf.node.connectionFailed(connectionSelf, err)
+	})
+}
+
+// RemoteActorRefDef A reference to an Actor on a remote node, it's an ActorRef & ActorHandle[interface{}]
+//
+// Its watchers are notified(ActorTerminated) when the connection to the remote node failed.
+type RemoteActorRefDef struct {
+	node    *RemoteNodeDef
+	address string
+	path    string
+
+	termination  *fpgo.PublisherDef[fpgo.ActorTerminated]
+	terminationM sync.Mutex
+}
+
+// GetID Get the ID (hash of the address & the path)
+func (refSelf *RemoteActorRefDef) GetID() uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(refSelf.address + refSelf.path))
+	return hash.Sum64()
+}
+
+// GetName Get the name
+func (refSelf *RemoteActorRefDef) GetName() string {
+	return path.Base(refSelf.path)
+}
+
+// GetPath Get the path on the remote node
+func (refSelf *RemoteActorRefDef) GetPath() string {
+	return refSelf.path
+}
+
+// GetAddress Get the address of the remote node
+func (refSelf *RemoteActorRefDef) GetAddress() string {
+	return refSelf.address
+}
+
+// Send Send a message to the remote Actor
+func (refSelf *RemoteActorRefDef) Send(message interface{}) {
+	refSelf.TellForInterface(message, nil)
+}
+
+// SendForInterface Send a message to the remote Actor, returns error if it's not sent
+func (refSelf *RemoteActorRefDef) SendForInterface(message interface{}) error {
+	return refSelf.TellForInterface(message, nil)
+}
+
+// TellForInterface Send a message with its sender to the remote Actor, returns error if it's not sent
+//
+// Local senders are sent only if the local node is listening (the remote Actor replies to it by connecting back).
+func (refSelf *RemoteActorRefDef) TellForInterface(message interface{}, sender fpgo.ActorRef) error {
+	frame := &remoteFrame{Kind: remoteFrameTell, Recipient: refSelf.path}
+	if remoteSender, ok := sender.(*RemoteActorRefDef); ok {
+		frame.SenderAddress = remoteSender.address
+		frame.Sender = remoteSender.path
+	} else if sender != nil {
+		frame.SenderAddress = refSelf.node.GetAddress()
+		frame.Sender = sender.GetPath()
+	}
+
+	return refSelf.node.send(refSelf.address, frame, message)
+}
+
+// AskWithContext Ask the remote Actor, waiting for the reply until ctx is done
+//
+// The remote Actor replies by GetSender().TellForInterface(response, self).
+// fpgo.ErrActorAskTimeout is returned if the deadline of ctx exceeded.
+func (refSelf *RemoteActorRefDef) AskWithContext(ctx context.Context, message interface{}) (interface{}, error) {
+	return refSelf.node.ask(ctx, refSelf, message)
+}
+
+// RemoteAskGenerics Ask the remote Actor, waiting for the reply of R until ctx is done
+func RemoteAskGenerics[R any](ctx context.Context, ref *RemoteActorRefDef, message interface{}) (R, error) {
+	var result R
+	response, err := ref.AskWithContext(ctx, message)
+	if err != nil {
+		return result, err
+	}
+
+	result, ok := response.(R)
+	if !ok {
+		return result, fpgo.ErrActorMessageType
+	}
+	return result, nil
+}
+
+// IsClosed Check is the local node closed
+func (refSelf *RemoteActorRefDef) IsClosed() bool {
+	return refSelf.node.isClosed.Get()
+}
+
+// Close Nothing happens: remote Actors can't be closed by references
+func (refSelf *RemoteActorRefDef) Close() {
+}
+
+// SubscribeTermination Subscribe the failure of the connection to the remote node
+func (refSelf *RemoteActorRefDef) SubscribeTermination(fn func(fpgo.ActorTerminated)) *fpgo.Subscription[fpgo.ActorTerminated] {
+	refSelf.terminationM.Lock()
+	defer refSelf.terminationM.Unlock()
+	return refSelf.termination.Subscribe(fpgo.Subscription[fpgo.ActorTerminated]{OnNext: fn})
+}
+
+// UnsubscribeTermination Unsubscribe the failure of the connection by the Subscription
+func (refSelf *RemoteActorRefDef) UnsubscribeTermination(s *fpgo.Subscription[fpgo.ActorTerminated]) {
+	refSelf.terminationM.Lock()
+	defer refSelf.terminationM.Unlock()
+	if s != nil {
+		refSelf.termination.Unsubscribe(s)
+	}
+}
+
+func (refSelf *RemoteActorRefDef) terminated(err error) {
+	// Watchers are notified once
+	refSelf.terminationM.Lock()
+	termination := refSelf.termination
+	refSelf.termination = fpgo.PublisherNewGenerics[fpgo.ActorTerminated]()
+	refSelf.terminationM.Unlock()
+
+	termination.Publish(fpgo.ActorTerminated{Actor: refSelf, Cause: err})
+}
+
+// remoteReplyRef The sender of remote Asks, replies are sent back to the asking node
+type remoteReplyRef struct {
+	connection    *remoteConnection
+	correlationID uint64
+}
+
+func (refSelf *remoteReplyRef) GetID() uint64 {
+	return refSelf.correlationID
+}
+func (refSelf *remoteReplyRef) GetName() string {
+	return fmt.Sprintf("ask-%d", refSelf.correlationID)
+}
+func (refSelf *remoteReplyRef) GetPath() string {
+	return "/temp/" + refSelf.GetName()
+}
+func (refSelf *remoteReplyRef) SendForInterface(message interface{}) error {
+	return refSelf.TellForInterface(message, nil)
+}
+func (refSelf *remoteReplyRef) TellForInterface(message interface{}, sender fpgo.ActorRef) error {
+	frame := &remoteFrame{Kind: remoteFrameReply, CorrelationID: refSelf.correlationID}
+	err := refSelf.connection.node.encodeMessage(frame, message)
+	if err != nil {
+		frame = &remoteFrame{Kind: remoteFrameReplyError, CorrelationID: refSelf.correlationID, Error: err.Error()}
+	}
+
+	refSelf.connection.write(frame)
+	return err
+}
+func (refSelf *remoteReplyRef) IsClosed() bool {
+	return false
+}
+func (refSelf *remoteReplyRef) Close() {
+}
+func (refSelf *remoteReplyRef) SubscribeTermination(fn func(fpgo.ActorTerminated)) *fpgo.Subscription[fpgo.ActorTerminated] {
+	return nil
+}
+func (refSelf *remoteReplyRef) UnsubscribeTermination(s *fpgo.Subscription[fpgo.ActorTerminated]) {
+}
@@ -0,0 +1,135 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	fpgo "github.com/TeaEntityLab/fpGo"
+)
+
+type remoteTestOrder struct {
+	ID     string
+	Amount int
+}
+
+type remoteTestUnknown struct {
+	Value int
+}
+
+func TestRemoteActor(t *testing.T) {
+	for _, codec := range []RemoteCodec{JSONRemoteCodec{}, GobRemoteCodec{}} {
+		systemA := fpgo.ActorSystem.New("a")
+		systemB := fpgo.ActorSystem.New("b")
+		fpgo.ActorOfGenerics(systemA, "echo", func(self *fpgo.ActorDef[interface{}], input interface{}) {
+			switch val := input.(type) {
+			case string:
+				if val != "ignore" {
+					self.GetSender().TellForInterface("echo:"+val, self)
+				}
+			case remoteTestOrder:
+				self.GetSender().TellForInterface(val.Amount*2, self)
+			}
+		}, nil)
+		collected := make(chan interface{}, 10)
+		collector, _ := fpgo.ActorOfGenerics(systemB, "collector", func(self *fpgo.ActorDef[interface{}], input interface{}) {
+			collected <- input
+		}, nil)
+
+		nodeA := NewRemoteNode(systemA, codec)
+		nodeB := NewRemoteNode(systemB, codec)
+		RemoteRegisterGenerics[remoteTestOrder](nodeA)
+		RemoteRegisterGenerics[remoteTestOrder](nodeB)
+		RemoteRegisterGenerics[remoteTestUnknown](nodeB)
+		assert.Equal(t, nil, nodeA.Listen("127.0.0.1:0"))
+		assert.Equal(t, nil, nodeB.Listen("127.0.0.1:0"))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+
+		// Ask
+		echo := nodeB.ActorOf(nodeA.GetAddress(), "/user/echo")
+		response, err := RemoteAskGenerics[string](ctx, echo, "hello")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "echo:hello", response)
+		amount, err := RemoteAskGenerics[int](ctx, echo, remoteTestOrder{ID: "1", Amount: 21})
+		assert.Equal(t, nil, err)
+		assert.Equal(t, 42, amount)
+
+		// Tell with the local sender, the reply comes back by another connection
+		assert.Equal(t, nil, echo.TellForInterface("world", collector))
+		assert.Equal(t, "echo:world", <-collected)
+
+		// Errors
+		_, err = nodeB.ActorOf(nodeA.GetAddress(), "/user/nobody").AskWithContext(ctx, "hello")
+		assert.Equal(t, ErrRemoteActorNotFound.Error(), err.Error())
+		_, err = echo.AskWithContext(ctx, remoteTestUnknown{Value: 1})
+		assert.Equal(t, ErrRemoteUnknownType.Error(), err.Error())
+		assert.Equal(t, ErrRemoteUnknownType, echo.SendForInterface(1.5i))
+		timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err = echo.AskWithContext(timeoutCtx, "ignore")
+		assert.Equal(t, fpgo.ErrActorAskTimeout, err)
+		timeoutCancel()
+
+		// Blocking Mailboxes of recipients don't stall the connection
+		gate := make(chan struct{})
+		fpgo.ActorOfGenerics(systemA, "slow", func(self *fpgo.ActorDef[interface{}], input interface{}) {
+			<-gate
+		}, &fpgo.ActorOption[interface{}]{Mailbox: &fpgo.MailboxOption[interface{}]{Capacity: 1, Overflow: fpgo.MailboxBlockWithTimeout}})
+		slow := nodeB.ActorOf(nodeA.GetAddress(), "/user/slow")
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, nil, slow.TellForInterface("wait", nil))
+		}
+		response, err = RemoteAskGenerics[string](ctx, echo, "unblocked")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "echo:unblocked", response)
+		// The queue of the recipient is bounded
+		for i := 0; i <= RemoteDeliveryCapacity; i++ {
+			slow.TellForInterface("wait", nil)
+		}
+		_, err = slow.AskWithContext(ctx, "wait")
+		assert.Equal(t, fpgo.ErrActorMailboxFull.Error(), err.Error())
+		close(gate)
+		// No queues for unknown recipients
+		nodeA.nodeM.Lock()
+		for connection := range nodeA.inbound {
+			connection.deliverersM.Lock()
+			assert.Equal(t, 2, len(connection.deliverers))
+			assert.Nil(t, connection.deliverers["/user/nobody"])
+			deliverer := connection.deliverers["/user/echo"]
+			connection.deliverersM.Unlock()
+
+			// Idle queues are released (nil: the idle message)
+			deliverer.Send(nil)
+			<-deliverer.Done()
+			connection.deliverersM.Lock()
+			assert.Nil(t, connection.deliverers["/user/echo"])
+			connection.deliverersM.Unlock()
+		}
+		nodeA.nodeM.Unlock()
+		response, err = RemoteAskGenerics[string](ctx, echo, "released")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, "echo:released", response)
+
+		// Connection failures
+		failures := make(chan RemoteConnectionFailure, 10)
+		nodeB.SubscribeConnectionFailure(func(failure RemoteConnectionFailure) {
+			failures <- failure
+		})
+		watcher, _ := fpgo.ActorOfGenerics(systemB, "watcher", func(self *fpgo.ActorDef[interface{}], input interface{}) {
+			collected <- input
+		}, nil)
+		watcher.Watch(echo)
+		nodeA.Close()
+		assert.Equal(t, nodeA.GetAddress(), (<-failures).Address)
+		terminated := (<-collected).(fpgo.ActorTerminated)
+		assert.Equal(t, fpgo.ActorRef(echo), terminated.Actor)
+		_, err = echo.AskWithContext(ctx, "hello")
+		assert.NotEqual(t, nil, err)
+
+		cancel()
+		nodeB.Close()
+		systemA.Shutdown()
+		systemB.Shutdown()
+	}
+}
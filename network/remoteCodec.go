@@ -0,0 +1,116 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	// RemoteMaxFrameSize Max size of a frame of remote Actors
+	RemoteMaxFrameSize = 16 * 1024 * 1024
+)
+
+var (
+	ErrRemoteFrameTooLarge = fmt.Errorf("ErrRemoteFrameTooLarge")
+)
+
+// RemoteCodec Codec of messages of remote Actors
+type RemoteCodec interface {
+	Encode(value interface{}) ([]byte, error)
+	// Decode Decode data into target(a pointer)
+	Decode(data []byte, target interface{}) error
+}
+
+// JSONRemoteCodec RemoteCodec by encoding/json
+type JSONRemoteCodec struct{}
+
+// Encode Encode the value by JSON
+func (codecSelf JSONRemoteCodec) Encode(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Decode Decode JSON data into target(a pointer)
+func (codecSelf JSONRemoteCodec) Decode(data []byte, target interface{}) error {
+	return json.Unmarshal(data, target)
+}
+
+// GobRemoteCodec RemoteCodec by encoding/gob
+type GobRemoteCodec struct{}
+
+// Encode Encode the value by gob
+func (codecSelf GobRemoteCodec) Encode(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(value)
+	return buffer.Bytes(), err
+}
+
+// Decode Decode gob data into target(a pointer)
+func (codecSelf GobRemoteCodec) Decode(data []byte, target interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(target)
+}
+
+// remoteFrameKind The kind of frames of remote Actors
+type remoteFrameKind int
+
+const (
+	remoteFrameTell remoteFrameKind = iota
+	remoteFrameAsk
+	remoteFrameReply
+	remoteFrameReplyError
+)
+
+// remoteFrame A frame of remote Actors, Payload is the encoded message of the Type
+type remoteFrame struct {
+	Kind          remoteFrameKind
+	Recipient     string
+	Sender        string
+	SenderAddress string
+	CorrelationID uint64
+	Type          string
+	Payload       []byte
+	Error         string
+}
+
+// writeRemoteFrame Write the length-prefixed(uint32, big endian) frame
+func writeRemoteFrame(writer io.Writer, codec RemoteCodec, frame *remoteFrame) error {
+	data, err := codec.Encode(frame)
+	if err != nil {
+		return err
+	}
+	if len(data) > RemoteMaxFrameSize {
+		return ErrRemoteFrameTooLarge
+	}
+
+	buffer := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(buffer, uint32(len(data)))
+	copy(buffer[4:], data)
+	_, err = writer.Write(buffer)
+	return err
+}
+
+// readRemoteFrame Read a length-prefixed(uint32, big endian) frame
+func readRemoteFrame(reader *bufio.Reader, codec RemoteCodec) (*remoteFrame, error) {
+	var header [4]byte
+	_, err := io.ReadFull(reader, header[:])
+	if err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > RemoteMaxFrameSize {
+		return nil, ErrRemoteFrameTooLarge
+	}
+
+	data := make([]byte, size)
+	_, err = io.ReadFull(reader, data)
+	if err != nil {
+		return nil, err
+	}
+	frame := &remoteFrame{}
+	err = codec.Decode(data, frame)
+	return frame, err
+}